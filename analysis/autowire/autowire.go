@@ -0,0 +1,408 @@
+// Package autowire defines an analyzer that reports ginject wiring mistakes
+// that would otherwise only surface when the container runs.
+//
+// It checks the syntax of autowire tags against what the container accepts,
+// flags tagged fields the container cannot set, validates Export calls whose
+// types are statically known, and reports Name literals that are registered
+// twice on the same container within a package.
+package autowire
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const bootPath = "github.com/esclipez/ginject/boot"
+
+const doc = `check ginject autowire tags and component registrations
+
+The autowire analyzer reports autowire tags the container would reject or
+misread, tagged fields the container cannot set, Export calls with types the
+instance does not implement, and component names registered twice on the
+same container within a package.`
+
+// Analyzer reports ginject wiring mistakes
+var Analyzer = &analysis.Analyzer{
+	Name:     "autowire",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var allowUnexported bool
+
+func init() {
+	Analyzer.Flags.BoolVar(&allowUnexported, "unexported", false,
+		"accept autowire tags on unexported fields (boot.WithUnexportedFieldInjection)")
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	regs := collectRegistrations(pass, inspect)
+	names := make(map[string]bool)
+	for _, reg := range regs {
+		if reg.name != "" {
+			names[reg.name] = true
+		}
+	}
+
+	inspect.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		checkStruct(pass, n.(*ast.StructType), names)
+	})
+	checkRegistrations(pass, regs)
+	return nil, nil
+}
+
+// checkStruct reports invalid autowire tags and unsettable tagged fields
+func checkStruct(pass *analysis.Pass, st *ast.StructType, names map[string]bool) {
+	for _, field := range st.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+		raw, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		tag, tagged := reflect.StructTag(raw).Lookup("autowire")
+		if !tagged {
+			continue
+		}
+
+		fieldType := pass.TypesInfo.TypeOf(field.Type)
+		for _, fieldName := range fieldNames(field) {
+			if fieldName == "_" {
+				pass.Reportf(field.Pos(), "autowire tag on blank field: the container cannot set it")
+				continue
+			}
+			if !ast.IsExported(fieldName) && !allowUnexported {
+				pass.Reportf(field.Pos(), "autowire tag on unexported field %s: export it or enable boot.WithUnexportedFieldInjection()", fieldName)
+			}
+		}
+		if msg := checkQualifier(tag, fieldType, pass.Pkg, names); msg != "" {
+			pass.Reportf(field.Tag.Pos(), "%s", msg)
+		}
+	}
+}
+
+// fieldNames returns the names of a field, or the type name of an embedded one
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) > 0 {
+		names := make([]string, len(field.Names))
+		for i, name := range field.Names {
+			names[i] = name.Name
+		}
+		return names
+	}
+	expr := field.Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return []string{t.Name}
+	case *ast.SelectorExpr:
+		return []string{t.Sel.Name}
+	case *ast.IndexExpr:
+		return fieldNames(&ast.Field{Type: t.X})
+	case *ast.IndexListExpr:
+		return fieldNames(&ast.Field{Type: t.X})
+	}
+	return nil
+}
+
+// checkQualifier validates an autowire tag the way the container interprets
+// it and returns a message describing the problem, if any
+func checkQualifier(tag string, fieldType types.Type, pkg *types.Package, names map[string]bool) string {
+	if strings.HasPrefix(tag, "@") {
+		for _, part := range strings.Split(tag, ",") {
+			part = strings.TrimSpace(part)
+			if part == "optional" || part == "?" {
+				continue
+			}
+			if !strings.HasPrefix(part, "@") || len(part) == 1 {
+				return fmt.Sprintf("invalid label selector %q in autowire tag %q", part, tag)
+			}
+		}
+		return ""
+	}
+
+	switch tag {
+	case "", "required", "optional", "?":
+		return ""
+	case "new":
+		if fieldType != nil && !isStructOrStructPointer(fieldType) {
+			return fmt.Sprintf(`autowire:"new" requires a struct or struct pointer field, got %s`,
+				types.TypeString(fieldType, types.RelativeTo(pkg)))
+		}
+		return ""
+	}
+	if strings.TrimSpace(tag) != tag {
+		return fmt.Sprintf("autowire tag %q has surrounding whitespace, which is part of the component name", tag)
+	}
+
+	name := strings.TrimSuffix(tag, ",optional")
+	if name == "" {
+		return fmt.Sprintf("autowire tag %q is missing the component name before ,optional", tag)
+	}
+	if i := strings.LastIndex(name, ","); i >= 0 {
+		option := name[i+1:]
+		return fmt.Sprintf("unknown autowire option %q in tag %q%s", option, tag, suggest(option, []string{"optional"}))
+	}
+	switch name {
+	case "required", "optional", "?":
+		if name != tag {
+			return ""
+		}
+	case "new":
+		return `autowire:"new" does not accept options`
+	}
+	if hint := suggest(name, []string{"required", "optional"}); hint != "" {
+		return fmt.Sprintf("autowire qualifier %q is resolved as a component name%s", name, hint)
+	}
+	if !names[name] {
+		candidates := make([]string, 0, len(names))
+		for candidate := range names {
+			candidates = append(candidates, candidate)
+		}
+		sort.Strings(candidates)
+		if hint := suggest(name, candidates); hint != "" {
+			return fmt.Sprintf("no component named %q is registered in this package%s", name, hint)
+		}
+	}
+	return ""
+}
+
+func isStructOrStructPointer(t types.Type) bool {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	_, ok := t.Underlying().(*types.Struct)
+	return ok
+}
+
+// suggest returns a "did you mean" hint for the closest candidate within
+// two edits of word
+func suggest(word string, candidates []string) string {
+	best, bestDistance := "", 3
+	for _, candidate := range candidates {
+		if candidate == word || len(candidate) < 4 {
+			continue
+		}
+		if d := editDistance(strings.ToLower(word), strings.ToLower(candidate)); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// registration is a chain of ObjectBuilder calls rooted at an Object call
+type registration struct {
+	container types.Object // nil for the default container
+	instance  types.Type
+	calls     []*ast.CallExpr
+	name      string
+	namePos   token.Pos
+}
+
+// collectRegistrations returns the builder chains of the package whose
+// Object call is visible, in source order
+func collectRegistrations(pass *analysis.Pass, inspect *inspector.Inspector) []*registration {
+	var regs []*registration
+	inspect.Preorder([]ast.Node{(*ast.ExprStmt)(nil), (*ast.AssignStmt)(nil)}, func(n ast.Node) {
+		var exprs []ast.Expr
+		switch stmt := n.(type) {
+		case *ast.ExprStmt:
+			exprs = []ast.Expr{stmt.X}
+		case *ast.AssignStmt:
+			exprs = stmt.Rhs
+		}
+		for _, expr := range exprs {
+			if reg := chainOf(pass, expr); reg != nil {
+				regs = append(regs, reg)
+			}
+		}
+	})
+	return regs
+}
+
+// chainOf returns the registration whose outermost builder call is expr
+func chainOf(pass *analysis.Pass, expr ast.Expr) *registration {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	var calls []*ast.CallExpr
+	for {
+		fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != bootPath {
+			return nil
+		}
+		if fn.Name() == "Object" {
+			if len(call.Args) != 1 {
+				return nil
+			}
+			reg := &registration{instance: pass.TypesInfo.TypeOf(call.Args[0])}
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && isMethod(fn) {
+				ident, ok := ast.Unparen(sel.X).(*ast.Ident)
+				if !ok {
+					return nil
+				}
+				reg.container = pass.TypesInfo.Uses[ident]
+			}
+			for i := len(calls) - 1; i >= 0; i-- {
+				reg.calls = append(reg.calls, calls[i])
+				if name, ok := nameArgument(pass, calls[i]); ok {
+					reg.name, reg.namePos = name, calls[i].Args[0].Pos()
+				}
+			}
+			return reg
+		}
+		if !isBuilderMethod(fn) {
+			return nil
+		}
+		calls = append(calls, call)
+		sel := call.Fun.(*ast.SelectorExpr)
+		next, ok := ast.Unparen(sel.X).(*ast.CallExpr)
+		if !ok {
+			return nil
+		}
+		call = next
+	}
+}
+
+// nameArgument returns the constant argument of a Name call
+func nameArgument(pass *analysis.Pass, call *ast.CallExpr) (string, bool) {
+	if call.Fun.(*ast.SelectorExpr).Sel.Name != "Name" || len(call.Args) != 1 {
+		return "", false
+	}
+	value := pass.TypesInfo.Types[call.Args[0]].Value
+	if value == nil || value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(value), true
+}
+
+func isMethod(fn *types.Func) bool {
+	return fn.Type().(*types.Signature).Recv() != nil
+}
+
+func isBuilderMethod(fn *types.Func) bool {
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return false
+	}
+	ptr, ok := recv.Type().(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	return ok && named.Obj().Name() == "ObjectBuilder"
+}
+
+// checkRegistrations validates Export calls and reports duplicate names
+func checkRegistrations(pass *analysis.Pass, regs []*registration) {
+	type scope struct {
+		container types.Object
+		name      string
+	}
+	seen := make(map[scope]token.Pos)
+
+	for _, reg := range regs {
+		replaces := false
+		for _, call := range reg.calls {
+			method := call.Fun.(*ast.SelectorExpr).Sel.Name
+			switch method {
+			case "Export":
+				checkExport(pass, reg, call)
+			case "Replaces":
+				replaces = true
+			}
+		}
+		if reg.name == "" || replaces {
+			continue
+		}
+		key := scope{container: reg.container, name: reg.name}
+		if previous, exists := seen[key]; exists {
+			pass.Reportf(reg.namePos, "component name %q is already registered at %s", reg.name, pass.Fset.Position(previous))
+			continue
+		}
+		seen[key] = reg.namePos
+	}
+}
+
+// checkExport reports Export calls the container would reject
+func checkExport(pass *analysis.Pass, reg *registration, call *ast.CallExpr) {
+	if len(call.Args) != 1 || reg.instance == nil || types.IsInterface(reg.instance) {
+		return
+	}
+	arg := call.Args[0]
+	tv := pass.TypesInfo.Types[arg]
+	if tv.IsNil() {
+		pass.Reportf(arg.Pos(), "cannot export nil type")
+		return
+	}
+	target := tv.Type
+	if target == nil || types.IsInterface(target) {
+		return
+	}
+	if ptr, ok := target.Underlying().(*types.Pointer); ok && types.IsInterface(ptr.Elem()) {
+		target = ptr.Elem()
+	}
+	if !types.AssignableTo(reg.instance, target) {
+		qualifier := types.RelativeTo(pass.Pkg)
+		pass.Reportf(arg.Pos(), "component type %s cannot be exported as %s%s", types.TypeString(reg.instance, qualifier),
+			types.TypeString(target, qualifier), missingMethod(reg.instance, target))
+	}
+}
+
+// missingMethod explains why t does not implement an interface
+func missingMethod(t, iface types.Type) string {
+	it, ok := iface.Underlying().(*types.Interface)
+	if !ok {
+		return ""
+	}
+	method, wrongType := types.MissingMethod(t, it, true)
+	if method == nil {
+		return ""
+	}
+	if wrongType {
+		return fmt.Sprintf(": method %s has the wrong signature", method.Name())
+	}
+	return fmt.Sprintf(": missing method %s", method.Name())
+}
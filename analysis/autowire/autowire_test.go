@@ -0,0 +1,13 @@
+package autowire_test
+
+import (
+	"testing"
+
+	"github.com/esclipez/ginject/analysis/autowire"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), autowire.Analyzer, "a")
+}
@@ -0,0 +1,58 @@
+package a
+
+import "github.com/esclipez/ginject/boot"
+
+type Logger interface {
+	Log(message string)
+}
+
+type Metrics interface {
+	Count(name string)
+}
+
+type ConsoleLogger struct{}
+
+func (l *ConsoleLogger) Log(string) {}
+
+type Options struct {
+	Verbose bool
+}
+
+type Service struct {
+	Default   Logger   `autowire:""`
+	Required  Logger   `autowire:"required"`
+	Optional  Logger   `autowire:"optional"`
+	Maybe     Logger   `autowire:"?"`
+	Named     Logger   `autowire:"console"`
+	NamedOpt  Logger   `autowire:"console,optional"`
+	Labeled   []Logger `autowire:"@tier=storage,@primary,optional"`
+	Options   *Options `autowire:"new"`
+	Untouched Logger
+
+	Typo      Logger   `autowire:"optinal"`          // want `autowire qualifier "optinal" is resolved as a component name \(did you mean "optional"\?\)`
+	Misnamed  Logger   `autowire:"consle"`           // want `no component named "consle" is registered in this package \(did you mean "console"\?\)`
+	Option    Logger   `autowire:"console,optinal"`  // want `unknown autowire option "optinal" in tag "console,optinal" \(did you mean "optional"\?\)`
+	Spaced    Logger   `autowire:" console"`         // want `autowire tag " console" has surrounding whitespace`
+	Nameless  Logger   `autowire:",optional"`        // want `autowire tag ",optional" is missing the component name`
+	Selector  []Logger `autowire:"@tier=storage,eu"` // want `invalid label selector "eu" in autowire tag "@tier=storage,eu"`
+	NotStruct Logger   `autowire:"new"`              // want `autowire:"new" requires a struct or struct pointer field, got Logger`
+	NewOpt    *Options `autowire:"new,optional"`     // want `autowire:"new" does not accept options`
+	hidden    Logger   `autowire:""`                 // want `autowire tag on unexported field hidden`
+	_         Logger   `autowire:""`                 // want `autowire tag on blank field`
+}
+
+func init() {
+	boot.Object(&ConsoleLogger{}).Name("console").Export((*Logger)(nil))
+	boot.Object(&ConsoleLogger{}).Export((*Metrics)(nil)) // want `component type \*ConsoleLogger cannot be exported as Metrics: missing method Count`
+	boot.Object(&ConsoleLogger{}).Export(nil)             // want `cannot export nil type`
+	boot.Object(&ConsoleLogger{}).Name("console")         // want `component name "console" is already registered at .*a.go:45:`
+	boot.Object(&ConsoleLogger{}).Name("console").Replaces("console")
+
+	var logger Logger = &ConsoleLogger{}
+	boot.Object(logger).Export((*Metrics)(nil))
+
+	container := boot.NewContainer()
+	container.Object(&Service{}).Name("console")
+	container.Object(&Service{}).Name("service").Primary()
+	container.Object(&Service{}).Name("service") // want `component name "service" is already registered`
+}
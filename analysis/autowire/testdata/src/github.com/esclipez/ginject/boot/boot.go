@@ -0,0 +1,17 @@
+// Package boot is a stub of the registration API checked by the analyzer
+package boot
+
+type Container struct{}
+
+func NewContainer() *Container { return &Container{} }
+
+func (c *Container) Object(instance interface{}) *ObjectBuilder { return &ObjectBuilder{} }
+
+type ObjectBuilder struct{}
+
+func Object(instance interface{}) *ObjectBuilder { return &ObjectBuilder{} }
+
+func (b *ObjectBuilder) Name(name string) *ObjectBuilder          { return b }
+func (b *ObjectBuilder) Export(typePtr interface{}) *ObjectBuilder { return b }
+func (b *ObjectBuilder) Primary() *ObjectBuilder                  { return b }
+func (b *ObjectBuilder) Replaces(name string) *ObjectBuilder      { return b }
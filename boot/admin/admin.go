@@ -0,0 +1,274 @@
+// Package admin provides an opt-in HTTP endpoint exposing the state of a ginject container.
+//
+// The server is a regular component:
+//
+//	boot.Object(admin.New("127.0.0.1:8086"))
+//
+// It serves:
+//
+//	GET  /components         registered components
+//	GET  /components/{name}  a component and its autowired fields
+//	GET  /state              container lifecycle state
+//	GET  /health             aggregated health report
+//	POST /shutdown           graceful shutdown through boot.Shutdown, only
+//	                         when enabled with WithShutdown
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/esclipez/ginject/boot"
+)
+
+// DefaultAddr is the address used when New is called with an empty address
+const DefaultAddr = "127.0.0.1:8086"
+
+// TokenHeader is the request header carrying the shutdown token
+const TokenHeader = "X-Ginject-Admin-Token"
+
+// DefaultReadHeaderTimeout bounds the time a client may take to send the
+// request headers when WithReadHeaderTimeout is not given
+const DefaultReadHeaderTimeout = 10 * time.Second
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithContainer exposes c instead of the default container
+func WithContainer(c *boot.Container) Option {
+	return func(s *Server) {
+		s.container = c
+	}
+}
+
+// WithShutdown enables POST /shutdown for requests carrying token in the
+// TokenHeader header. Browsers cannot send the custom header cross-origin
+// without a CORS preflight, which the server never allows. An empty token
+// leaves the endpoint disabled.
+func WithShutdown(token string) Option {
+	return func(s *Server) {
+		s.shutdownToken = token
+	}
+}
+
+// WithReadHeaderTimeout sets the time a client may take to send the request
+// headers, protecting the server from slow clients holding connections open
+func WithReadHeaderTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.readHeaderTimeout = timeout
+	}
+}
+
+// Server is a Startable and Stoppable component serving the admin endpoints
+type Server struct {
+	addr              string
+	container         *boot.Container
+	shutdownToken     string
+	readHeaderTimeout time.Duration
+
+	mu       sync.Mutex
+	server   *http.Server
+	listener net.Listener
+}
+
+// New creates an admin server listening on addr once started
+func New(addr string, opts ...Option) *Server {
+	if addr == "" {
+		addr = DefaultAddr
+	}
+	s := &Server{addr: addr, readHeaderTimeout: DefaultReadHeaderTimeout}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Name returns the component name of the admin server
+func (s *Server) Name() string {
+	return "ginject.admin"
+}
+
+// Start listens on the configured address and serves requests in the background
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("admin: failed to listen on %s: %w", s.addr, err)
+	}
+
+	server := &http.Server{Handler: s.Handler(), ReadHeaderTimeout: s.readHeaderTimeout}
+	s.mu.Lock()
+	s.server = server
+	s.listener = listener
+	s.mu.Unlock()
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.target().Logger().Errorf("admin: server failed: %v", err)
+		}
+	}()
+	s.target().Logger().Infof("admin: listening on %s", listener.Addr())
+	return nil
+}
+
+// Stop shuts the server down gracefully
+func (s *Server) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	server := s.server
+	s.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+	return server.Shutdown(ctx)
+}
+
+// Addr returns the address the server listens on, or the configured address
+// before it has started
+func (s *Server) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listener != nil {
+		return s.listener.Addr().String()
+	}
+	return s.addr
+}
+
+// Handler returns the HTTP handler serving the admin endpoints
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /components", s.handleComponents)
+	mux.HandleFunc("GET /components/{name...}", s.handleComponent)
+	mux.HandleFunc("GET /state", s.handleState)
+	mux.HandleFunc("GET /health", s.handleHealth)
+	if s.shutdownToken != "" {
+		mux.HandleFunc("POST /shutdown", s.handleShutdown)
+	}
+	return mux
+}
+
+// target returns the exposed container, resolved lazily so the default
+// container can be reset before startup
+func (s *Server) target() *boot.Container {
+	if s.container != nil {
+		return s.container
+	}
+	return boot.Default()
+}
+
+func (s *Server) handleComponents(w http.ResponseWriter, r *http.Request) {
+	components := s.target().Components()
+	views := make([]componentView, len(components))
+	for i, info := range components {
+		views[i] = newComponentView(info, false)
+	}
+	s.writeJSON(w, http.StatusOK, views)
+}
+
+func (s *Server) handleComponent(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	for _, info := range s.target().Components() {
+		if info.Name == name {
+			s.writeJSON(w, http.StatusOK, newComponentView(info, true))
+			return
+		}
+	}
+	s.writeJSON(w, http.StatusNotFound, errorView{Error: fmt.Sprintf("component '%s' not found", name)})
+}
+
+func (s *Server) handleState(w http.ResponseWriter, r *http.Request) {
+	s.writeJSON(w, http.StatusOK, stateView{State: s.target().State()})
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	report := s.target().Health(r.Context())
+	status := http.StatusOK
+	if report.Status != boot.HealthUp {
+		status = http.StatusServiceUnavailable
+	}
+	s.writeJSON(w, status, report)
+}
+
+func (s *Server) handleShutdown(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get(TokenHeader)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.shutdownToken)) != 1 {
+		s.writeJSON(w, http.StatusForbidden, errorView{Error: "missing or invalid " + TokenHeader + " header"})
+		return
+	}
+	boot.Shutdown()
+	s.writeJSON(w, http.StatusAccepted, stateView{State: s.target().State()})
+}
+
+type componentView struct {
+	Name          string            `json:"name"`
+	Type          string            `json:"type"`
+	ExportedTypes []string          `json:"exportedTypes"`
+	Priority      int               `json:"priority"`
+	Primary       bool              `json:"primary"`
+	Fallback      bool              `json:"fallback"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	DependsOn     []string          `json:"dependsOn,omitempty"`
+	Dependencies  []dependencyView  `json:"dependencies,omitempty"`
+}
+
+type dependencyView struct {
+	Field      string   `json:"field"`
+	Type       string   `json:"type"`
+	Qualifier  string   `json:"qualifier"`
+	Components []string `json:"components"`
+}
+
+type stateView struct {
+	State boot.State `json:"state"`
+}
+
+type errorView struct {
+	Error string `json:"error"`
+}
+
+// newComponentView converts a component to its JSON view, with its autowired
+// fields when detailed is set
+func newComponentView(info *boot.ComponentInfo, detailed bool) componentView {
+	view := componentView{
+		Name:          info.Name,
+		Type:          info.InstanceType.String(),
+		ExportedTypes: make([]string, len(info.ExportedTypes)),
+		Priority:      info.Priority,
+		Primary:       info.IsPrimary,
+		Fallback:      info.IsFallback,
+		Labels:        info.Labels,
+		DependsOn:     info.DependsOn,
+	}
+	for i, t := range info.ExportedTypes {
+		view.ExportedTypes[i] = t.String()
+	}
+	if detailed {
+		view.Dependencies = make([]dependencyView, len(info.Dependencies))
+		for i, dependency := range info.Dependencies {
+			view.Dependencies[i] = dependencyView{
+				Field:      dependency.Field,
+				Type:       dependency.Type.String(),
+				Qualifier:  dependency.Qualifier,
+				Components: append([]string{}, dependency.Components...),
+			}
+		}
+	}
+	return view
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		s.target().Logger().Errorf("admin: failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/esclipez/ginject/boot"
+	"github.com/esclipez/ginject/boot/boottest"
+)
+
+type logger interface {
+	Log(string)
+}
+
+type consoleLogger struct{}
+
+func (l *consoleLogger) Log(string) {}
+
+type app struct {
+	Logger logger `autowire:""`
+	Audit  logger `autowire:"audit,optional"`
+}
+
+func newTestContainer() *boot.Container {
+	container := boot.NewContainer()
+	container.Object(&consoleLogger{}).Export((*logger)(nil)).Name("console").Priority(10).Primary().Label("output", "stdout")
+	container.Object(&app{}).Name("app")
+	return container
+}
+
+func getJSON(t *testing.T, handler http.Handler, method, path string, status int, v interface{}) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+	if recorder.Code != status {
+		t.Fatalf("expected %s %s to return %d, got %d: %s", method, path, status, recorder.Code, recorder.Body)
+	}
+	if v != nil {
+		if err := json.Unmarshal(recorder.Body.Bytes(), v); err != nil {
+			t.Fatalf("expected JSON from %s, got %v", path, err)
+		}
+	}
+}
+
+func TestHandlerExposesComponentsAndDependencies(t *testing.T) {
+	container := newTestContainer()
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	defer container.Stop(context.Background())
+	handler := New("", WithContainer(container)).Handler()
+
+	var components []componentView
+	getJSON(t, handler, http.MethodGet, "/components", http.StatusOK, &components)
+	expected := []componentView{
+		{Name: "console", Type: "*admin.consoleLogger", ExportedTypes: []string{"*admin.consoleLogger", "admin.logger"}, Priority: 10, Primary: true,
+			Labels: map[string]string{"output": "stdout"}},
+		{Name: "app", Type: "*admin.app", ExportedTypes: []string{"*admin.app"}},
+	}
+	if !reflect.DeepEqual(components, expected) {
+		t.Fatalf("expected components %+v, got %+v", expected, components)
+	}
+
+	var detail componentView
+	getJSON(t, handler, http.MethodGet, "/components/app", http.StatusOK, &detail)
+	expectedDependencies := []dependencyView{
+		{Field: "Logger", Type: "admin.logger", Qualifier: "", Components: []string{"console"}},
+		{Field: "Audit", Type: "admin.logger", Qualifier: "audit,optional", Components: []string{}},
+	}
+	if !reflect.DeepEqual(detail.Dependencies, expectedDependencies) {
+		t.Fatalf("expected dependencies %+v, got %+v", expectedDependencies, detail.Dependencies)
+	}
+
+	getJSON(t, handler, http.MethodGet, "/components/missing", http.StatusNotFound, nil)
+
+	var state map[string]string
+	getJSON(t, handler, http.MethodGet, "/state", http.StatusOK, &state)
+	if state["state"] != "running" {
+		t.Fatalf("expected running state, got %v", state)
+	}
+}
+
+func TestServerShutdownEndpointStopsApplication(t *testing.T) {
+	container := newTestContainer()
+	server := New("127.0.0.1:0", WithContainer(container), WithShutdown("secret"))
+	container.Object(server)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- boot.Run(context.Background(), boot.WithContainer(container), boot.WithSignals())
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for container.State() != boot.StateRunning {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected container to start, state is %s", container.State())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	request, _ := http.NewRequest(http.MethodPost, "http://"+server.Addr()+"/shutdown", nil)
+	request.Header.Set(TokenHeader, "secret")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("expected shutdown request to succeed, got %v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusAccepted {
+		t.Fatalf("expected 202 from shutdown, got %d", response.StatusCode)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expected clean shutdown, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected shutdown endpoint to stop the application")
+	}
+}
+
+func TestShutdownEndpointIsDisabledByDefault(t *testing.T) {
+	getJSON(t, New("", WithContainer(newTestContainer())).Handler(), http.MethodPost, "/shutdown", http.StatusNotFound, nil)
+}
+
+func TestShutdownEndpointRejectsMissingOrInvalidToken(t *testing.T) {
+	handler := New("", WithContainer(newTestContainer()), WithShutdown("secret")).Handler()
+
+	for _, token := range []string{"", "wrong"} {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/shutdown", nil)
+		if token != "" {
+			request.Header.Set(TokenHeader, token)
+		}
+		handler.ServeHTTP(recorder, request)
+		if recorder.Code != http.StatusForbidden {
+			t.Fatalf("expected 403 for token %q, got %d", token, recorder.Code)
+		}
+	}
+}
+
+func TestServerLogsThroughContainerLogger(t *testing.T) {
+	logger := boottest.NewLogger()
+	container := boot.NewContainer(boot.WithLogger(logger))
+	server := New("127.0.0.1:0", WithContainer(container), WithReadHeaderTimeout(time.Second))
+	container.Object(server)
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	defer container.Stop(context.Background())
+
+	expected := []string{"admin: listening on " + server.Addr()}
+	if got := logger.Messages(boottest.LevelInfo); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v through the container logger, got %v", expected, got)
+	}
+	if timeout := server.server.ReadHeaderTimeout; timeout != time.Second {
+		t.Fatalf("expected configured read header timeout, got %s", timeout)
+	}
+}
+
+func TestNewSetsDefaultReadHeaderTimeout(t *testing.T) {
+	if timeout := New("").readHeaderTimeout; timeout != DefaultReadHeaderTimeout {
+		t.Fatalf("expected default read header timeout, got %s", timeout)
+	}
+}
@@ -2,25 +2,91 @@ package boot
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/signal"
 	"reflect"
-	"syscall"
+	"sync"
 )
 
 var (
+	defaultMu        sync.Mutex
 	defaultContainer = NewContainer()
 	shutdownChan     = make(chan struct{}, 1)
+
+	// exitFunc terminates the process on a forced exit
+	exitFunc = os.Exit
 )
 
+// Default returns the container used by the package-level functions
+func Default() *Container {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	return defaultContainer
+}
+
+// ResetDefault replaces the default container with a new, empty one and
+// discards any pending Shutdown request. A running default container is not
+// stopped; stop it first if needed.
+func ResetDefault() {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultContainer = NewContainer()
+	shutdownChan = make(chan struct{}, 1)
+}
+
+// WithDefaultContainer makes c the default container while fn runs and restores
+// the previous default container afterwards. It must not be used concurrently.
+func WithDefaultContainer(c *Container, fn func()) {
+	defaultMu.Lock()
+	previous := defaultContainer
+	defaultContainer = c
+	defaultMu.Unlock()
+
+	defer func() {
+		defaultMu.Lock()
+		defaultContainer = previous
+		defaultMu.Unlock()
+	}()
+	fn()
+}
+
+// defaultForRegistration returns the default container, panicking with a hint
+// when it has already been run so registrations do not leak across runs
+func defaultForRegistration() *Container {
+	c := Default()
+	if c.isSealed() {
+		panic("cannot register component on the default container after it has started; call boot.ResetDefault() to start over")
+	}
+	return c
+}
+
+// shutdownSignal returns the channel Shutdown sends on
+func shutdownSignal() chan struct{} {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	return shutdownChan
+}
+
 // Object provides global access to component registration
 func Object(instance interface{}) *ObjectBuilder {
-	return defaultContainer.Object(instance)
+	return defaultForRegistration().Object(instance)
+}
+
+// Override registers a replacement for an existing component on the default container
+func Override(target interface{}, instance interface{}) *ObjectBuilder {
+	return defaultForRegistration().Override(target, instance)
+}
+
+// Decorate registers a decorator for an exported type on the default container
+func Decorate(typePtr interface{}, decorator interface{}) {
+	defaultForRegistration().Decorate(typePtr, decorator)
 }
 
 // GetByName retrieves a component by name from the default container
 func GetByName(name string) (interface{}, error) {
-	return defaultContainer.GetByName(name)
+	return Default().GetByName(name)
 }
 
 // GetByType retrieves a component by type from the default container
@@ -29,7 +95,7 @@ func GetByType(componentType interface{}) (interface{}, error) {
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem() // Get the interface type
 	}
-	return defaultContainer.GetByType(t)
+	return Default().GetByType(t)
 }
 
 // GetAllByType retrieves all components by type from the default container
@@ -38,46 +104,142 @@ func GetAllByType(componentType interface{}) ([]interface{}, error) {
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem() // Get the interface type
 	}
-	return defaultContainer.GetAllByType(t)
+	return Default().GetAllByType(t)
+}
+
+// FindByLabel returns the components of the default container labelled key=value
+func FindByLabel(key, value string) []*ComponentInfo {
+	return Default().FindByLabel(key, value)
+}
+
+// Health aggregates the health of the default container
+func Health(ctx context.Context) HealthReport {
+	return Default().Health(ctx)
 }
 
 // Shutdown triggers graceful shutdown of the application
 func Shutdown() {
 	select {
-	case shutdownChan <- struct{}{}:
+	case shutdownSignal() <- struct{}{}:
 		// Signal sent successfully
 	default:
 		// Channel already has a signal, ignore
 	}
 }
 
-// RunApplication starts the application with the default container
-func RunApplication() {
-	ctx := context.Background()
+// Run starts the application with the default container, or with the
+// container given by WithContainer, and blocks until shutdown is requested by
+// an OS signal, Shutdown, or cancellation of ctx. Startup and shutdown errors
+// are returned so the caller decides how to exit. With GINJECT_MODE=inspect,
+// Run reports the wiring and exits instead (see ModeInspect); in a dry run
+// (GINJECT_MODE=dry-run, WithDryRun, or a container created with
+// WithDryRunMode) it returns after Container.DryRun.
+func Run(ctx context.Context, opts ...RunOption) error {
+	cfg := newRunConfig(opts)
+	container := cfg.container
+
+	mode, err := runMode()
+	if err != nil {
+		return err
+	}
+	if mode == ModeInspect {
+		inspectAndExit(container)
+		return nil
+	}
+	if mode == ModeDryRun || cfg.dryRun || container.dryRun {
+		if err := container.DryRun(); err != nil {
+			return fmt.Errorf("dry run failed: %w", err)
+		}
+		return nil
+	}
 
 	// Run the complete lifecycle
-	Info("ginject: starting application")
-	if err := defaultContainer.Run(ctx); err != nil {
-		Fatalf("ginject: startup failed: %v", err)
+	container.log().Info("ginject: starting application")
+	if err := container.Run(ctx); err != nil {
+		if errors.Is(err, ErrAlreadyRun) && container == Default() {
+			return fmt.Errorf("startup failed: %w (call boot.ResetDefault() to run the default container again)", err)
+		}
+		return fmt.Errorf("startup failed: %w", err)
 	}
 
-	Info("ginject: application started")
+	container.log().Info("ginject: application started")
 
-	// Wait for shutdown signal (either OS signal or programmatic shutdown)
+	// Wait for shutdown signal (OS signal, programmatic shutdown or context cancellation)
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	if len(cfg.signals) > 0 {
+		signal.Notify(sigChan, cfg.signals...)
+		defer signal.Stop(sigChan)
+	}
 
-	select {
-	case <-sigChan:
-		Info("ginject: shutdown requested by OS signal")
-	case <-shutdownChan:
-		Info("ginject: shutdown requested")
+	var reloadChan chan os.Signal
+	if cfg.reloadHook != nil {
+		reloadChan = make(chan os.Signal, 1)
+		signal.Notify(reloadChan, cfg.reloadSignals...)
+		defer signal.Stop(reloadChan)
 	}
 
-	// Graceful shutdown
-	Info("ginject: stopping application")
-	if err := defaultContainer.Stop(ctx); err != nil {
-		Errorf("ginject: shutdown failed: %v", err)
+	failure := waitForShutdown(ctx, cfg, container, sigChan, reloadChan)
+
+	// A shutdown signal during graceful shutdown forces the process to exit
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-sigChan:
+			container.log().Errorf("ginject: forced exit, components not stopped: %v", container.pendingStop())
+			exitFunc(cfg.forceExitCode)
+		case <-stopped:
+		}
+	}()
+
+	// Graceful shutdown, not cut short by the cancellation that may have triggered it
+	container.log().Info("ginject: stopping application")
+	err = container.Stop(context.WithoutCancel(ctx))
+	close(stopped)
+	if err != nil {
+		err = fmt.Errorf("shutdown failed: %w", err)
+	}
+	if failure != nil {
+		return errors.Join(fmt.Errorf("application failed: %w", failure), err)
+	}
+	if err != nil {
+		return err
+	}
+	container.log().Info("ginject: application stopped")
+	return nil
+}
+
+// waitForShutdown blocks until shutdown is requested, running the reload hook
+// for every reload signal received in the meantime. It returns the component
+// failure that caused the shutdown, if any.
+func waitForShutdown(ctx context.Context, cfg *runConfig, container *Container, sigChan, reloadChan <-chan os.Signal) error {
+	for {
+		select {
+		case <-sigChan:
+			container.log().Info("ginject: shutdown requested by OS signal")
+			return nil
+		case <-shutdownSignal():
+			container.log().Info("ginject: shutdown requested")
+			return nil
+		case <-ctx.Done():
+			container.log().Info("ginject: shutdown requested by context")
+			return nil
+		case err := <-container.Failed():
+			container.log().Info("ginject: shutdown requested by component failure")
+			return err
+		case <-reloadChan:
+			container.log().Info("ginject: reload requested by OS signal")
+			if err := cfg.reloadHook(ctx); err != nil {
+				container.log().Errorf("ginject: reload failed: %v", err)
+			}
+		}
+	}
+}
+
+// RunApplication starts the application like Run with a background context
+// and exits the process through Fatalf if startup or shutdown fails
+func RunApplication(opts ...RunOption) {
+	cfg := newRunConfig(opts)
+	if err := Run(context.Background(), opts...); err != nil {
+		cfg.container.log().Fatalf("ginject: %v", err)
 	}
-	Info("ginject: application stopped")
 }
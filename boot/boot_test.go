@@ -2,8 +2,10 @@ package boot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -15,6 +17,7 @@ func (c *runApplicationShutdownComponent) Start(context.Context) error {
 }
 
 type capturingLogger struct {
+	debug []string
 	info  []string
 	error []string
 	fatal []string
@@ -22,7 +25,9 @@ type capturingLogger struct {
 
 func (l *capturingLogger) Debug(args ...interface{}) {}
 
-func (l *capturingLogger) Debugf(format string, args ...interface{}) {}
+func (l *capturingLogger) Debugf(format string, args ...interface{}) {
+	l.debug = append(l.debug, fmt.Sprintf(format, args...))
+}
 
 func (l *capturingLogger) Info(args ...interface{}) {
 	l.info = append(l.info, fmt.Sprint(args...))
@@ -88,3 +93,242 @@ func TestRunApplicationLogsCleanLifecycleMessages(t *testing.T) {
 		t.Fatalf("expected no fatal logs, got %v", logger.fatal)
 	}
 }
+
+func restoreDefaults(t *testing.T) {
+	oldContainer := defaultContainer
+	oldShutdownChan := shutdownChan
+	oldLogger := defaultLogger
+	t.Cleanup(func() {
+		defaultContainer = oldContainer
+		shutdownChan = oldShutdownChan
+		defaultLogger = oldLogger
+	})
+}
+
+func TestResetDefaultDiscardsRegistrationsAndShutdownRequests(t *testing.T) {
+	restoreDefaults(t)
+
+	ResetDefault()
+	Object(&containerRunConsoleLogger{}).Name("logger")
+	Shutdown()
+
+	ResetDefault()
+	if err := Default().Run(context.Background()); err != nil {
+		t.Fatalf("expected reset default container to run, got %v", err)
+	}
+	if _, err := GetByName("logger"); err == nil {
+		t.Fatal("expected registrations before ResetDefault to be discarded")
+	}
+	select {
+	case <-shutdownSignal():
+		t.Fatal("expected pending shutdown request to be discarded")
+	default:
+	}
+}
+
+func TestObjectPanicsWithResetHintOnSealedDefaultContainer(t *testing.T) {
+	restoreDefaults(t)
+
+	ResetDefault()
+	if err := Default().Run(context.Background()); err != nil {
+		t.Fatalf("expected default container to run, got %v", err)
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil || !strings.Contains(fmt.Sprint(r), "boot.ResetDefault()") {
+			t.Fatalf("expected panic mentioning ResetDefault, got %v", r)
+		}
+	}()
+	Object(&containerRunConsoleLogger{})
+}
+
+func TestDefaultContainerRejectsSecondRun(t *testing.T) {
+	restoreDefaults(t)
+	ResetDefault()
+
+	if err := Default().Run(context.Background()); err != nil {
+		t.Fatalf("expected first run to succeed, got %v", err)
+	}
+	if err := Default().Stop(context.Background()); err != nil {
+		t.Fatalf("expected stop to succeed, got %v", err)
+	}
+	if err := Default().Run(context.Background()); !errors.Is(err, ErrAlreadyRun) {
+		t.Fatalf("expected ErrAlreadyRun, got %v", err)
+	}
+}
+
+func TestContainerRunsAgainAfterStop(t *testing.T) {
+	container := NewContainer()
+	component := &contextRecordingStopComponent{}
+	container.Object(component).Name("component")
+
+	for i := 0; i < 2; i++ {
+		if err := container.Run(context.Background()); err != nil {
+			t.Fatalf("expected run %d to succeed, got %v", i+1, err)
+		}
+		if err := container.Stop(context.Background()); err != nil {
+			t.Fatalf("expected stop %d to succeed, got %v", i+1, err)
+		}
+	}
+	if state := container.State(); state != StateStopped {
+		t.Fatalf("expected container to be stopped, got %s", state)
+	}
+}
+
+func TestWithDefaultContainerSwapsAndRestoresDefault(t *testing.T) {
+	restoreDefaults(t)
+
+	previous := Default()
+	container := NewContainer()
+	WithDefaultContainer(container, func() {
+		Object(&containerRunConsoleLogger{}).Name("scoped")
+		if Default() != container {
+			t.Fatal("expected supplied container to be the default inside fn")
+		}
+	})
+
+	if Default() != previous {
+		t.Fatal("expected previous default container to be restored")
+	}
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected supplied container to run, got %v", err)
+	}
+	if _, err := container.GetByName("scoped"); err != nil {
+		t.Fatalf("expected registration to target supplied container, got %v", err)
+	}
+}
+
+func TestRunApplicationRunsSuppliedContainer(t *testing.T) {
+	restoreDefaults(t)
+
+	defaultLogger = &capturingLogger{}
+	ResetDefault()
+	container := NewContainer()
+	container.Object(&runApplicationShutdownComponent{})
+
+	RunApplication(WithContainer(container))
+
+	if state := container.State(); state != StateStopped {
+		t.Fatalf("expected supplied container to have been run, got state %s", state)
+	}
+	if Default().isSealed() {
+		t.Fatal("expected default container to be left untouched")
+	}
+}
+
+type failingStartComponent struct{}
+
+func (c *failingStartComponent) Start(context.Context) error {
+	return errors.New("boom")
+}
+
+type failingStopComponent struct{}
+
+func (c *failingStopComponent) Stop(context.Context) error {
+	return errors.New("stuck")
+}
+
+type contextRecordingStopComponent struct {
+	stopErr error
+}
+
+func (c *contextRecordingStopComponent) Stop(ctx context.Context) error {
+	c.stopErr = ctx.Err()
+	return nil
+}
+
+func TestRunReturnsStartupError(t *testing.T) {
+	restoreDefaults(t)
+	defaultLogger = &capturingLogger{}
+
+	container := NewContainer()
+	container.Object(&failingStartComponent{})
+
+	err := Run(context.Background(), WithContainer(container))
+	if err == nil || !strings.Contains(err.Error(), "startup failed") || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("expected startup error, got %v", err)
+	}
+}
+
+func TestRunStopsOnContextCancellation(t *testing.T) {
+	restoreDefaults(t)
+	logger := &capturingLogger{}
+	defaultLogger = logger
+
+	ctx, cancel := context.WithCancel(context.Background())
+	component := &contextRecordingStopComponent{}
+	container := NewContainer()
+	container.Object(component)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Run(ctx, WithContainer(container))
+	}()
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatalf("expected clean shutdown, got %v", err)
+	}
+	if component.stopErr != nil {
+		t.Fatalf("expected Stop to receive an uncancelled context, got %v", component.stopErr)
+	}
+}
+
+func TestRunLogsThroughContainerLogger(t *testing.T) {
+	restoreDefaults(t)
+	packageLogger := &capturingLogger{}
+	defaultLogger = packageLogger
+
+	logger := &capturingLogger{}
+	container := NewContainer(WithLogger(logger))
+	container.Object(&contextRecordingStopComponent{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Run(ctx, WithContainer(container)); err != nil {
+		t.Fatalf("expected clean shutdown, got %v", err)
+	}
+
+	expectedInfo := []string{
+		"ginject: starting application",
+		"ginject: application started",
+		"ginject: shutdown requested by context",
+		"ginject: stopping application",
+		"ginject: application stopped",
+	}
+	if !reflect.DeepEqual(logger.info, expectedInfo) {
+		t.Fatalf("expected info logs %v, got %v", expectedInfo, logger.info)
+	}
+	if len(packageLogger.info) != 0 || len(packageLogger.error) != 0 {
+		t.Fatalf("expected package-level logger to stay silent, got %v %v", packageLogger.info, packageLogger.error)
+	}
+}
+
+func TestRunReturnsShutdownError(t *testing.T) {
+	restoreDefaults(t)
+	defaultLogger = &capturingLogger{}
+	ResetDefault()
+
+	container := NewContainer()
+	container.Object(&failingStopComponent{})
+	container.Object(&runApplicationShutdownComponent{})
+
+	err := Run(context.Background(), WithContainer(container))
+	if err == nil || !strings.Contains(err.Error(), "shutdown failed") || !strings.Contains(err.Error(), "stuck") {
+		t.Fatalf("expected shutdown error, got %v", err)
+	}
+}
+
+func TestRunStopsAndReturnsRunnableFailure(t *testing.T) {
+	restoreDefaults(t)
+	defaultLogger = &capturingLogger{}
+
+	container := NewContainer(WithLogger(&capturingLogger{}))
+	container.Object(&failingLoopComponent{}).Name("consumer")
+
+	err := Run(context.Background(), WithContainer(container))
+	if err == nil || !strings.Contains(err.Error(), "application failed: runner 'consumer' failed") {
+		t.Fatalf("expected runner failure to be returned, got %v", err)
+	}
+}
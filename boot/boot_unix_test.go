@@ -0,0 +1,105 @@
+//go:build unix
+
+package boot
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type blockingStopComponent struct {
+	stopping chan struct{}
+	release  chan struct{}
+}
+
+func (c *blockingStopComponent) Stop(context.Context) error {
+	close(c.stopping)
+	<-c.release
+	return nil
+}
+
+func TestRunForcesExitOnSignalDuringShutdown(t *testing.T) {
+	restoreDefaults(t)
+	logger := &capturingLogger{}
+	defaultLogger = logger
+	ResetDefault()
+
+	oldExitFunc := exitFunc
+	t.Cleanup(func() { exitFunc = oldExitFunc })
+	exitCode := make(chan int, 1)
+	component := &blockingStopComponent{stopping: make(chan struct{}), release: make(chan struct{})}
+	exitFunc = func(code int) {
+		exitCode <- code
+		close(component.release)
+	}
+
+	container := NewContainer()
+	container.Object(component).Name("blocking")
+	container.Object(&runApplicationShutdownComponent{})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Run(context.Background(), WithContainer(container), WithSignals(syscall.SIGUSR1), WithForceExitCode(7))
+	}()
+
+	<-component.stopping
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("failed to send signal: %v", err)
+	}
+
+	if code := <-exitCode; code != 7 {
+		t.Fatalf("expected forced exit code 7, got %d", code)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("expected Run to finish once stop is released, got %v", err)
+	}
+	if len(logger.error) != 1 || !strings.Contains(logger.error[0], "[blocking]") {
+		t.Fatalf("expected forced exit log naming the pending component, got %v", logger.error)
+	}
+}
+
+func TestRunCallsReloadHookOnReloadSignal(t *testing.T) {
+	restoreDefaults(t)
+	defaultLogger = &capturingLogger{}
+	ResetDefault()
+
+	// Keep the default action of SIGUSR2 from terminating the test binary
+	// if it arrives before Run subscribes to it
+	guard := make(chan os.Signal, 1)
+	signal.Notify(guard, syscall.SIGUSR2)
+	defer signal.Stop(guard)
+
+	reloaded := make(chan struct{})
+	reload := func(context.Context) error {
+		close(reloaded)
+		Shutdown()
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Run(context.Background(), WithContainer(NewContainer()), WithSignals(), WithReload(reload, syscall.SIGUSR2))
+	}()
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for waiting := true; waiting; {
+		select {
+		case <-reloaded:
+			waiting = false
+		case <-ticker.C:
+			if err := syscall.Kill(os.Getpid(), syscall.SIGUSR2); err != nil {
+				t.Fatalf("failed to send signal: %v", err)
+			}
+		}
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("expected clean shutdown after reload, got %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+// Package boottest provides a harness for testing applications wired with ginject.
+package boottest
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/esclipez/ginject/boot"
+)
+
+// Option configures a Harness created with New
+type Option func(*config)
+
+type config struct {
+	ctx              context.Context
+	containerOptions []boot.ContainerOption
+	setup            []func(c *boot.Container)
+}
+
+// Context sets the context passed to Run and Stop (defaults to context.Background())
+func Context(ctx context.Context) Option {
+	return func(cfg *config) {
+		cfg.ctx = ctx
+	}
+}
+
+// ContainerOptions passes options to boot.NewContainer
+func ContainerOptions(opts ...boot.ContainerOption) Option {
+	return func(cfg *config) {
+		cfg.containerOptions = append(cfg.containerOptions, opts...)
+	}
+}
+
+// Setup registers components on the container, typically the production wiring
+func Setup(fn func(c *boot.Container)) Option {
+	return func(cfg *config) {
+		cfg.setup = append(cfg.setup, fn)
+	}
+}
+
+// Object registers a component, optionally configuring its builder
+func Object(instance interface{}, configure ...func(b *boot.ObjectBuilder)) Option {
+	return Setup(func(c *boot.Container) {
+		builder := c.Object(instance)
+		for _, fn := range configure {
+			fn(builder)
+		}
+	})
+}
+
+// Override replaces a registered component, identified by name or type pointer, with a fake
+func Override(target interface{}, instance interface{}) Option {
+	return Setup(func(c *boot.Container) {
+		c.Override(target, instance)
+	})
+}
+
+// Harness is a running container bound to a test
+type Harness struct {
+	Container *boot.Container
+	Logger    *Logger
+
+	t      testing.TB
+	ctx    context.Context
+	mu     sync.Mutex
+	events []boot.LifecycleEvent
+}
+
+// New builds a container with a recording logger and lifecycle observer,
+// applies the options, and runs it. The test fails immediately if Run fails. The container is stopped
+// when the test finishes, and the test fails if Stop returns an error.
+func New(t testing.TB, opts ...Option) *Harness {
+	t.Helper()
+
+	cfg := &config{ctx: context.Background()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	h := &Harness{Logger: NewLogger(), t: t, ctx: cfg.ctx}
+	containerOptions := append([]boot.ContainerOption{
+		boot.WithLogger(h.Logger),
+		boot.WithLifecycleObserver(h.record),
+	}, cfg.containerOptions...)
+	h.Container = boot.NewContainer(containerOptions...)
+	for _, fn := range cfg.setup {
+		fn(h.Container)
+	}
+
+	t.Cleanup(h.Stop)
+	if err := h.Container.Run(cfg.ctx); err != nil {
+		t.Fatalf("boottest: container failed to run: %v", err)
+	}
+	return h
+}
+
+func (h *Harness) record(event boot.LifecycleEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+}
+
+// Events returns the lifecycle calls the container made, in the order they returned
+func (h *Harness) Events() []boot.LifecycleEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]boot.LifecycleEvent{}, h.events...)
+}
+
+// LifecycleOrder returns the names of components in the order the container
+// completed the given phase on them
+func (h *Harness) LifecycleOrder(phase boot.Phase) []string {
+	var names []string
+	for _, event := range h.Events() {
+		if event.Phase == phase {
+			names = append(names, event.Component)
+		}
+	}
+	return names
+}
+
+// Stop stops the container before the test finishes and fails the test on
+// error. Stopping an already stopped container is a no-op.
+func (h *Harness) Stop() {
+	h.t.Helper()
+	if err := h.Container.Stop(h.ctx); err != nil {
+		h.t.Errorf("boottest: container failed to stop: %v", err)
+	}
+}
+
+// AssertResolves fails the test unless the container resolves a component for
+// type T, and returns the resolved component
+func AssertResolves[T any](t testing.TB, h *Harness) T {
+	t.Helper()
+
+	var zero T
+	componentType := reflect.TypeOf((*T)(nil)).Elem()
+	component, err := h.Container.GetByType(componentType)
+	if err != nil {
+		t.Fatalf("boottest: expected %s to resolve: %v", componentType, err)
+		return zero
+	}
+	resolved, ok := component.(T)
+	if !ok {
+		t.Fatalf("boottest: expected %s to resolve, got %T", componentType, component)
+		return zero
+	}
+	return resolved
+}
+
+// AssertLifecycleOrder fails the test unless the named components went through
+// the given phase in the given relative order. Components that were not
+// listed are ignored.
+func AssertLifecycleOrder(t testing.TB, h *Harness, phase boot.Phase, names ...string) {
+	t.Helper()
+
+	actual := h.LifecycleOrder(phase)
+	positions := make(map[string]int, len(actual))
+	for i, name := range actual {
+		positions[name] = i
+	}
+
+	last := -1
+	for _, name := range names {
+		position, exists := positions[name]
+		if !exists {
+			t.Fatalf("boottest: component '%s' did not %s (%s order: %v)", name, phase, phase, actual)
+			return
+		}
+		if position < last {
+			t.Fatalf("boottest: expected %s order %v, got %v", phase, names, actual)
+			return
+		}
+		last = position
+	}
+}
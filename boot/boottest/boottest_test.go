@@ -0,0 +1,111 @@
+package boottest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/esclipez/ginject/boot"
+)
+
+type greeter interface {
+	Greet() string
+}
+
+type englishGreeter struct{}
+
+func (g *englishGreeter) Greet() string {
+	return "hello"
+}
+
+type fakeGreeter struct{}
+
+func (g *fakeGreeter) Greet() string {
+	return "fake"
+}
+
+type lifecycleComponent struct {
+	Greeter greeter `autowire:""`
+	stopped bool
+}
+
+func (c *lifecycleComponent) Init(context.Context) error {
+	return nil
+}
+
+func (c *lifecycleComponent) Start(context.Context) error {
+	return nil
+}
+
+func (c *lifecycleComponent) Stop(context.Context) error {
+	c.stopped = true
+	return nil
+}
+
+type secondLifecycleComponent struct {
+	lifecycleComponent
+}
+
+func productionWiring(c *boot.Container) {
+	c.Object(&englishGreeter{}).Export((*greeter)(nil)).Name("greeter")
+}
+
+func TestNewRunsContainerWithOverrides(t *testing.T) {
+	app := &lifecycleComponent{}
+	h := New(t,
+		Setup(productionWiring),
+		Override("greeter", &fakeGreeter{}),
+		Object(app, func(b *boot.ObjectBuilder) { b.Name("app") }),
+	)
+
+	resolved := AssertResolves[greeter](t, h)
+	if got := resolved.Greet(); got != "fake" {
+		t.Fatalf("expected fake greeter to resolve, got %q", got)
+	}
+	if app.Greeter != resolved {
+		t.Fatal("expected fake greeter to be injected")
+	}
+}
+
+func TestAssertLifecycleOrderUsesRecordedPhases(t *testing.T) {
+	first := &lifecycleComponent{}
+	second := &secondLifecycleComponent{}
+	h := New(t,
+		Setup(productionWiring),
+		Object(first, func(b *boot.ObjectBuilder) { b.Name("first").Priority(10) }),
+		Object(second, func(b *boot.ObjectBuilder) { b.Name("second") }),
+	)
+
+	AssertLifecycleOrder(t, h, boot.PhaseInit, "first", "second")
+	AssertLifecycleOrder(t, h, boot.PhaseStart, "first", "second")
+
+	h.Stop()
+	if !first.stopped || !second.stopped {
+		t.Fatal("expected Stop to stop all components")
+	}
+	AssertLifecycleOrder(t, h, boot.PhaseStop, "second", "first")
+
+	expected := []string{"second", "first"}
+	if got := h.LifecycleOrder(boot.PhaseStop); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected stop order %v, got %v", expected, got)
+	}
+	if debug := h.Logger.Messages(LevelDebug); len(debug) != 0 {
+		t.Fatalf("expected no per-component debug logging by default, got %v", debug)
+	}
+}
+
+func TestLoggerRecordsLevels(t *testing.T) {
+	logger := NewLogger()
+	logger.Infof("started %s", "app")
+	logger.Error("failed")
+	logger.Fatal("fatal")
+
+	expected := []Entry{
+		{Level: LevelInfo, Message: "started app"},
+		{Level: LevelError, Message: "failed"},
+		{Level: LevelFatal, Message: "fatal"},
+	}
+	if got := logger.Entries(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected entries %v, got %v", expected, got)
+	}
+}
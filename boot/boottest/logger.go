@@ -0,0 +1,103 @@
+package boottest
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Log levels recorded by Logger
+const (
+	LevelDebug = "DEBUG"
+	LevelInfo  = "INFO"
+	LevelWarn  = "WARN"
+	LevelError = "ERROR"
+	LevelFatal = "FATAL"
+)
+
+// Entry is a single message recorded by Logger
+type Entry struct {
+	Level   string
+	Message string
+}
+
+// Logger implements boot.Logger by recording every message in memory.
+// Fatal messages are recorded without exiting the process.
+type Logger struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// NewLogger creates an empty recording logger
+func NewLogger() *Logger {
+	return &Logger{}
+}
+
+// Entries returns a copy of all recorded entries in order
+func (l *Logger) Entries() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries := make([]Entry, len(l.entries))
+	copy(entries, l.entries)
+	return entries
+}
+
+// Messages returns the recorded messages of the given level in order
+func (l *Logger) Messages(level string) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var messages []string
+	for _, entry := range l.entries {
+		if entry.Level == level {
+			messages = append(messages, entry.Message)
+		}
+	}
+	return messages
+}
+
+func (l *Logger) record(level, message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, Entry{Level: level, Message: message})
+}
+
+func (l *Logger) Debug(args ...interface{}) {
+	l.record(LevelDebug, fmt.Sprint(args...))
+}
+
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.record(LevelDebug, fmt.Sprintf(format, args...))
+}
+
+func (l *Logger) Info(args ...interface{}) {
+	l.record(LevelInfo, fmt.Sprint(args...))
+}
+
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.record(LevelInfo, fmt.Sprintf(format, args...))
+}
+
+func (l *Logger) Warn(args ...interface{}) {
+	l.record(LevelWarn, fmt.Sprint(args...))
+}
+
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	l.record(LevelWarn, fmt.Sprintf(format, args...))
+}
+
+func (l *Logger) Error(args ...interface{}) {
+	l.record(LevelError, fmt.Sprint(args...))
+}
+
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	l.record(LevelError, fmt.Sprintf(format, args...))
+}
+
+func (l *Logger) Fatal(args ...interface{}) {
+	l.record(LevelFatal, fmt.Sprint(args...))
+}
+
+func (l *Logger) Fatalf(format string, args ...interface{}) {
+	l.record(LevelFatal, fmt.Sprintf(format, args...))
+}
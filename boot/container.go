@@ -2,10 +2,14 @@ package boot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 	"sync"
+	"time"
+	"unsafe"
 )
 
 // ComponentInfo holds metadata about a registered component
@@ -16,12 +20,51 @@ type ComponentInfo struct {
 	Priority      int
 	ExportedTypes []reflect.Type
 	IsPrimary     bool
+	// IsFallback marks a default implementation used only when no other
+	// component exports the same type
+	IsFallback    bool
+	RestartPolicy RestartPolicy
+	// DependsOn names components that must be initialized and started before
+	// this one and stopped after it
+	DependsOn []string
+	// Labels holds the labels and tags of the component; tags have an empty value
+	Labels map[string]string
+	// InjectMethods lists the methods invoked for method injection after field injection
+	InjectMethods []string
+	// Dependencies lists the autowired fields of the component, filled in during injection
+	Dependencies []Dependency
+
+	// replacement is the instance consumers receive once a post-processor
+	// replaced the component; lifecycle calls still go to Instance
+	replacement interface{}
+}
+
+// exposed returns the instance consumers of type t receive: the replacement
+// set by a post-processor when it is assignable to t (or t is nil), otherwise
+// the component itself
+func (info *ComponentInfo) exposed(t reflect.Type) interface{} {
+	if info.replacement != nil && (t == nil || reflect.TypeOf(info.replacement).AssignableTo(t)) {
+		return info.replacement
+	}
+	return info.Instance
+}
+
+// Dependency describes an autowired field and the components injected into it
+type Dependency struct {
+	// Field is the field path, e.g. "Cache" or "Embedded.Logger" for nested structs
+	Field string
+	Type  reflect.Type
+	// Qualifier is the raw autowire tag value
+	Qualifier string
+	// Components names the injected components; empty for an unresolved optional field
+	Components []string
 }
 
 type ExportedComponentsInfo struct {
 	ExportedType reflect.Type
 	Primary      *ComponentInfo
 	Components   []*ComponentInfo
+	decorated    map[*ComponentInfo]interface{}
 }
 
 // Container manages the IoC lifecycle
@@ -30,20 +73,52 @@ type Container struct {
 	componentsByType map[reflect.Type]*ExportedComponentsInfo
 	components       []*ComponentInfo
 	pendingBuilders  []*ObjectBuilder
+	decorators       map[reflect.Type][]reflect.Value
+	decoratorErr     error
+	logger           Logger
+	stopTimeout      time.Duration
+	healthTimeout    time.Duration
+	parallel         bool
+	injectUnexported bool
+	allocateNested   bool
+	implicitIfaces   bool
+	dryRun           bool
+	observers        []LifecycleObserver
+	parallelLimit    int
+	runners          map[*ComponentInfo]*runner
+	failures         chan error
 	mu               sync.RWMutex
 	lifecycleMu      sync.Mutex
+	stateMu          sync.Mutex
+	state            State
+	stopPending      []string
 	sealed           bool
 	started          bool
+	ran              bool
 }
 
+// ErrAlreadyRun is returned by Run when the default container has already
+// been run. The default container runs once, so registrations cannot leak
+// from one run into the next; call ResetDefault to start over. Other
+// containers may be run again after Stop.
+var ErrAlreadyRun = errors.New("container has already been run")
+
 // NewContainer creates a new IoC container
-func NewContainer() *Container {
-	return &Container{
+func NewContainer(opts ...ContainerOption) *Container {
+	c := &Container{
 		componentByName:  make(map[string]*ComponentInfo),
 		componentsByType: make(map[reflect.Type]*ExportedComponentsInfo),
 		components:       make([]*ComponentInfo, 0),
 		pendingBuilders:  make([]*ObjectBuilder, 0),
+		decorators:       make(map[reflect.Type][]reflect.Value),
+		stopTimeout:      DefaultStopTimeout,
+		healthTimeout:    DefaultHealthTimeout,
+		failures:         make(chan error, 1),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // Object starts the fluent API for component registration
@@ -61,6 +136,13 @@ func (c *Container) Object(instance interface{}) *ObjectBuilder {
 	return builder
 }
 
+// isSealed reports whether registration is closed
+func (c *Container) isSealed() bool {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	return c.sealed
+}
+
 // registerComponent adds a component to the container
 func (c *Container) registerComponent(info *ComponentInfo) error {
 	c.mu.Lock()
@@ -88,7 +170,7 @@ func (c *Container) GetByName(name string) (interface{}, error) {
 	if !exists {
 		return nil, fmt.Errorf("component '%s' not found", name)
 	}
-	return info.Instance, nil
+	return info.exposed(nil), nil
 }
 
 // GetByType retrieves a component by type
@@ -96,11 +178,20 @@ func (c *Container) GetByType(componentType reflect.Type) (interface{}, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	info, exists := c.componentsByType[componentType]
-	if !exists {
-		return nil, fmt.Errorf("no component of type '%s' found", componentType)
-	}
-	return info.Primary.Instance, nil
+	component, _, err := c.getByTypeUnsafe(componentType, nil)
+	return component, err
+}
+
+// Components returns the registered components in registration order. The
+// returned ComponentInfo values are shared with the container and must be
+// treated as read-only.
+func (c *Container) Components() []*ComponentInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	components := make([]*ComponentInfo, len(c.components))
+	copy(components, c.components)
+	return components
 }
 
 // GetAllByType retrieves all components by type
@@ -114,85 +205,139 @@ func (c *Container) GetAllByType(componentType reflect.Type) ([]interface{}, err
 	}
 	components := make([]interface{}, len(info.Components))
 	for i, component := range info.Components {
-		components[i] = component.Instance
+		components[i] = info.instance(component)
 	}
 	return components, nil
 }
 
-// InjectDependencies performs dependency injection on all components
+// InjectDependencies performs dependency injection on all components: fields
+// first, then injection methods
 func (c *Container) InjectDependencies() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if err := c.injectAllUnsafe(); err != nil {
+		return err
+	}
+	for _, info := range c.components {
+		if err := c.injectMethodsUnsafe(info); err != nil {
+			return fmt.Errorf("failed to inject dependencies for '%s': %w", info.Name, err)
+		}
+	}
+	return nil
+}
+
+// injectAllUnsafe injects the fields of every registered component without locking
+func (c *Container) injectAllUnsafe() error {
 	for _, info := range c.components {
-		if err := c.injectComponentUnsafe(info.Instance); err != nil {
+		state := newInjectionState(info, injectionPlanSize(info.Instance))
+		if err := c.injectComponentUnsafe(info.Instance, "", state); err != nil {
 			return fmt.Errorf("failed to inject dependencies for '%s': %w", info.Name, err)
 		}
+		info.Dependencies = state.dependencies
 	}
 	return nil
 }
 
-// injectComponentUnsafe performs injection without locking (assumes caller holds lock)
-func (c *Container) injectComponentUnsafe(component interface{}) error {
+// injectComponentUnsafe performs injection without locking (assumes caller holds lock),
+// recording every autowired field under the given field path prefix. The
+// fields to visit come from the cached injection plan of the struct type.
+func (c *Container) injectComponentUnsafe(component interface{}, path string, state *injectionState) error {
 	v := reflect.ValueOf(component)
-	if v.Kind() != reflect.Ptr {
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	if !state.visit(v) {
+		// Already injected through another path, e.g. a self-referential pointer
 		return nil
 	}
 
 	v = v.Elem()
-	t := v.Type()
+	for _, fp := range planFor(v.Type()).fields {
+		field := v.Field(fp.index)
 
-	for i := 0; i < v.NumField(); i++ {
-		field := v.Field(i)
-		fieldType := t.Field(i)
-
-		// Check if autowire tag exists (including empty values)
-		if tag, exists := fieldType.Tag.Lookup("autowire"); exists {
-			if !field.CanSet() {
+		if !fp.tagged {
+			if !fp.exported && !c.injectUnexported {
 				continue
 			}
+			if err := c.injectFieldRecursively(field, path+fp.name+".", state); err != nil {
+				return fmt.Errorf("failed to inject field %s: %w", fp.name, err)
+			}
+			continue
+		}
+
+		settable, ok := c.settableField(field)
+		if !ok {
+			return fmt.Errorf("cannot autowire unexported field %s: export it or enable boot.WithUnexportedFieldInjection()",
+				path+fp.name)
+		}
+		field = settable
 
-			// Parse for optional syntax
-			isOptional := tag == "optional" || tag == "?" ||
-				(len(tag) > 9 && tag[len(tag)-9:] == ",optional")
-
-			dependency, err := c.resolveDependencyUnsafe(field.Type(), tag)
-			if err != nil {
-				if isOptional {
-					// Optional dependency - skip if not found, no error
-					continue
-				}
-				// Required dependency - fail if not found
-				return fmt.Errorf("failed to autowire required field %s: %w", fieldType.Name, err)
+		if fp.allocate {
+			if err := c.injectNewField(field, path+fp.name, state); err != nil {
+				return err
 			}
+			continue
+		}
 
-			if dependency != nil {
-				field.Set(reflect.ValueOf(dependency))
+		record := Dependency{Field: path + fp.name, Type: field.Type(), Qualifier: fp.qualifier}
+		var dependency interface{}
+		var sources []*ComponentInfo
+		var err error
+		switch {
+		case fp.selectorErr != nil:
+			err = fp.selectorErr
+		case fp.selectors != nil:
+			dependency, sources, err = c.resolveByLabelUnsafe(field.Type(), fp.selectors)
+		default:
+			var source *ComponentInfo
+			dependency, source, err = c.resolveDependencyUnsafe(field.Type(), fp.qualifier, state.consumer)
+			sources = []*ComponentInfo{source}
+		}
+		if err != nil {
+			if fp.optional {
+				// Optional dependency - skip if not found, no error
+				state.dependencies = append(state.dependencies, record)
+				continue
 			}
-		} else {
-			if err := c.injectFieldRecursively(field); err != nil {
-				return fmt.Errorf("failed to inject field %s: %w", fieldType.Name, err)
+			// Required dependency - fail if not found
+			return fmt.Errorf("failed to autowire required field %s: %w", fp.name, err)
+		}
+
+		if dependency != nil {
+			field.Set(reflect.ValueOf(dependency))
+			for _, source := range sources {
+				record.Components = append(record.Components, source.Name)
 			}
 		}
+		state.dependencies = append(state.dependencies, record)
 	}
 	return nil
 }
 
 // injectFieldRecursively recursively checks and injects dependencies for a field
-func (c *Container) injectFieldRecursively(field reflect.Value) error {
+func (c *Container) injectFieldRecursively(field reflect.Value, path string, state *injectionState) error {
 	// If field is not settable or invalid, return directly
-	if !field.CanSet() || !field.IsValid() {
+	if !field.IsValid() {
+		return nil
+	}
+	field, ok := c.settableField(field)
+	if !ok {
 		return nil
 	}
 
 	// Handle pointer types
 	if field.Kind() == reflect.Ptr {
 		if field.IsNil() {
-			// If it's a nil pointer, skip injection
-			return nil
+			// Nil pointers are skipped unless nested allocation is enabled.
+			// The injection plan only keeps structs declaring autowire fields.
+			if !c.allocateNested || state.allocating[field.Type().Elem()] {
+				return nil
+			}
+			return c.allocateAndInject(field, path, state)
 		}
 		// Recursively inject the value pointed to by the pointer
-		return c.injectComponentUnsafe(field.Interface())
+		return c.injectComponentUnsafe(field.Interface(), path, state)
 	}
 
 	// Handle struct types
@@ -201,25 +346,38 @@ func (c *Container) injectFieldRecursively(field reflect.Value) error {
 		if !field.CanAddr() {
 			return nil
 		}
-		return c.injectComponentUnsafe(field.Addr().Interface())
+		return c.injectComponentUnsafe(field.Addr().Interface(), path, state)
 	}
 
 	return nil
 }
 
-// resolveDependencyUnsafe resolves dependency without locking (assumes caller holds lock)
-func (c *Container) resolveDependencyUnsafe(fieldType reflect.Type, qualifier string) (interface{}, error) {
+// settableField returns a settable view of a field. Unexported fields are
+// accessed through unsafe only when unexported field injection is enabled.
+func (c *Container) settableField(field reflect.Value) (reflect.Value, bool) {
+	if field.CanSet() {
+		return field, true
+	}
+	if !c.injectUnexported || !field.CanAddr() {
+		return field, false
+	}
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem(), true
+}
+
+// resolveDependencyUnsafe resolves dependency of consumer without locking (assumes caller holds lock),
+// returning the resolved value and the component it came from
+func (c *Container) resolveDependencyUnsafe(fieldType reflect.Type, qualifier string, consumer *ComponentInfo) (interface{}, *ComponentInfo, error) {
 	// Parse qualifier for optional syntax: "ComponentName,optional"
 	componentName := qualifier
 	isOptional := false
 
 	if qualifier == "optional" || qualifier == "?" {
 		// Pure optional - resolve by type
-		dependency, err := c.getByTypeUnsafe(fieldType)
+		dependency, source, err := c.getByTypeUnsafe(fieldType, consumer)
 		if err != nil {
-			return nil, nil // Return nil without error for optional
+			return nil, nil, nil // Return nil without error for optional
 		}
-		return dependency, nil
+		return dependency, source, nil
 	}
 
 	// Check for "ComponentName,optional" syntax
@@ -231,64 +389,293 @@ func (c *Container) resolveDependencyUnsafe(fieldType reflect.Type, qualifier st
 	switch componentName {
 	case "required", "":
 		// Default is required - resolve by type
-		return c.getByTypeUnsafe(fieldType)
+		return c.getByTypeUnsafe(fieldType, consumer)
 	default:
 		// Specific component name
-		component, err := c.getByNameUnsafe(componentName)
+		component, source, err := c.getByNameForTypeUnsafe(componentName, fieldType)
 		if err != nil {
 			if isOptional {
-				return nil, nil // Return nil without error for optional named component
+				return nil, nil, nil // Return nil without error for optional named component
 			}
-			return nil, err
+			return nil, nil, err
 		}
 
 		// Type check: verify component can be assigned to target type
 		componentValue := reflect.ValueOf(component)
 		if !componentValue.Type().AssignableTo(fieldType) {
 			if isOptional {
-				return nil, nil // Return nil without error for optional incompatible type
+				return nil, nil, nil // Return nil without error for optional incompatible type
 			}
-			return nil, fmt.Errorf("component '%s' (type %s) is not assignable to field type %s",
+			return nil, nil, fmt.Errorf("component '%s' (type %s) is not assignable to field type %s",
 				componentName, componentValue.Type(), fieldType)
 		}
 
-		return component, nil
+		return component, source, nil
 	}
 }
 
-// getByNameUnsafe retrieves a component by name without locking
-func (c *Container) getByNameUnsafe(name string) (interface{}, error) {
+// getByNameForTypeUnsafe retrieves a named component as seen by consumers of
+// the given type, applying that type's decorators, without locking
+func (c *Container) getByNameForTypeUnsafe(name string, componentType reflect.Type) (interface{}, *ComponentInfo, error) {
 	info, exists := c.componentByName[name]
 	if !exists {
-		return nil, fmt.Errorf("component '%s' not found", name)
+		return nil, nil, fmt.Errorf("component '%s' not found", name)
 	}
-	return info.Instance, nil
+	if exported, exists := c.componentsByType[componentType]; exists {
+		return exported.instance(info), info, nil
+	}
+	return info.exposed(componentType), info, nil
 }
 
-// getByTypeUnsafe retrieves a component by type without locking
-func (c *Container) getByTypeUnsafe(componentType reflect.Type) (interface{}, error) {
+// getByTypeUnsafe retrieves a component by type for consumer, which may be
+// nil, without locking
+func (c *Container) getByTypeUnsafe(componentType reflect.Type, consumer *ComponentInfo) (interface{}, *ComponentInfo, error) {
 	info, exists := c.componentsByType[componentType]
 	if !exists {
-		return nil, fmt.Errorf("no component of type '%s' found", componentType)
+		if c.implicitIfaces && componentType.Kind() == reflect.Interface {
+			return c.getImplicitUnsafe(componentType, consumer)
+		}
+		return nil, nil, fmt.Errorf("no component of type '%s' found", componentType)
 	}
-	return info.Primary.Instance, nil
+	return info.instance(info.Primary), info.Primary, nil
 }
 
-// Initialize runs init phase in descending priority order (higher priority first)
+// Initialize runs init phase in descending priority order (higher priority first).
+// Post-processors and the components they depend on, directly or indirectly,
+// are initialized first in that order; the post-processors are then invoked
+// around the Init call of each remaining component.
 func (c *Container) Initialize(ctx context.Context) error {
 	components := c.getSortedComponents(false) // descending order
 
+	early := postProcessorDependencies(components)
+	if len(early) == 0 {
+		return c.runPhase(ctx, components, c.initComponent)
+	}
+
+	var processors []*ComponentInfo
+	var others []*ComponentInfo
+	for _, info := range components {
+		if !early[info] {
+			others = append(others, info)
+			continue
+		}
+		if err := c.initComponent(ctx, info); err != nil {
+			return err
+		}
+		if _, ok := info.Instance.(ComponentPostProcessor); ok {
+			processors = append(processors, info)
+		}
+	}
+
+	// Replacing an instance rewires the consumers that are not initialized
+	// yet, so post-processed components are always initialized one at a time
+	initialized := make(map[*ComponentInfo]bool, len(components))
+	for info := range early {
+		initialized[info] = true
+	}
+	for _, info := range others {
+		if err := c.initProcessedComponent(ctx, info, processors, initialized); err != nil {
+			return err
+		}
+		initialized[info] = true
+	}
+	return nil
+}
+
+// postProcessorDependencies returns the post-processors and the components
+// they depend on through injection or DependsOn, directly or indirectly
+func postProcessorDependencies(components []*ComponentInfo) map[*ComponentInfo]bool {
+	byName := make(map[string]*ComponentInfo, len(components))
 	for _, info := range components {
-		if initializable, ok := info.Instance.(Initializable); ok {
-			if err := initializable.Init(ctx); err != nil {
-				return fmt.Errorf("initialization failed for '%s': %w", info.Name, err)
+		byName[info.Name] = info
+	}
+
+	result := make(map[*ComponentInfo]bool)
+	var visit func(info *ComponentInfo)
+	visit = func(info *ComponentInfo) {
+		if result[info] {
+			return
+		}
+		result[info] = true
+		for _, name := range dependencyNames(info) {
+			if dependency, exists := byName[name]; exists {
+				visit(dependency)
+			}
+		}
+	}
+	for _, info := range components {
+		if _, ok := info.Instance.(ComponentPostProcessor); ok {
+			visit(info)
+		}
+	}
+	return result
+}
+
+// initComponent calls Init on a component if it is Initializable
+func (c *Container) initComponent(ctx context.Context, info *ComponentInfo) error {
+	if initializable, ok := info.Instance.(Initializable); ok {
+		if err := c.callHook(info, PhaseInit, func() error { return initializable.Init(ctx) }); err != nil {
+			return fmt.Errorf("initialization failed for '%s': %w", info.Name, err)
+		}
+	}
+	return nil
+}
+
+// initProcessedComponent runs BeforeInit, Init and AfterInit for a component,
+// replacing it whenever a post-processor returns a different instance. Init
+// is always called on the component itself. A panicking post-processor fails
+// with a *ComponentPanicError naming the post-processor.
+func (c *Container) initProcessedComponent(ctx context.Context, info *ComponentInfo, processors []*ComponentInfo,
+	initialized map[*ComponentInfo]bool) error {
+	instance := info.exposed(nil)
+	for _, processorInfo := range processors {
+		processor := processorInfo.Instance.(ComponentPostProcessor)
+		var processed interface{}
+		err := c.recoverPanic(processorInfo, PhaseBeforeInit, func() (err error) {
+			processed, err = processor.BeforeInit(info, instance)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("post-processing failed for '%s': %w", info.Name, err)
+		}
+		instance = processed
+	}
+	if err := c.replaceInstance(info, instance, initialized); err != nil {
+		return err
+	}
+
+	if err := c.initComponent(ctx, info); err != nil {
+		return err
+	}
+
+	instance = info.exposed(nil)
+	for _, processorInfo := range processors {
+		processor := processorInfo.Instance.(ComponentPostProcessor)
+		var processed interface{}
+		err := c.recoverPanic(processorInfo, PhaseAfterInit, func() (err error) {
+			processed, err = processor.AfterInit(info, instance)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("post-processing failed for '%s': %w", info.Name, err)
+		}
+		instance = processed
+	}
+	return c.replaceInstance(info, instance, initialized)
+}
+
+// replaceInstance makes consumers of a component receive instance instead of
+// it and rewires the autowired fields referencing it in components that are
+// not initialized yet. Injection methods are not called again, so their
+// parameters keep the original. Lifecycle calls keep going to the component itself,
+// and consumers of a decorated type keep the decorated component.
+func (c *Container) replaceInstance(info *ComponentInfo, instance interface{}, initialized map[*ComponentInfo]bool) error {
+	if instance == nil {
+		return fmt.Errorf("post-processing failed for '%s': nil instance returned", info.Name)
+	}
+	if sameInstance(info.exposed(nil), instance) {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Every exported type other than the component's own concrete type must
+	// still be satisfied by the replacement
+	instanceType := reflect.TypeOf(instance)
+	for _, exportedType := range info.ExportedTypes {
+		if exportedType != info.InstanceType && !instanceType.AssignableTo(exportedType) {
+			return fmt.Errorf("post-processing failed for '%s': replacement type %s cannot be exported as %s",
+				info.Name, instanceType, exportedType)
+		}
+	}
+
+	info.replacement = instance
+	if sameInstance(info.Instance, instance) {
+		info.replacement = nil
+	}
+
+	for _, consumer := range c.components {
+		if initialized[consumer] {
+			continue
+		}
+		for i := range consumer.Dependencies {
+			dependency := &consumer.Dependencies[i]
+			if !containsString(dependency.Components, info.Name) || isMethodDependency(dependency) {
+				continue
+			}
+			if err := c.rewireFieldUnsafe(consumer, dependency); err != nil {
+				return fmt.Errorf("post-processing failed for '%s': failed to rewire %s of '%s': %w",
+					info.Name, dependency.Field, consumer.Name, err)
 			}
 		}
 	}
 	return nil
 }
 
-// Start runs startup phase in descending priority order (higher priority first)
+// isMethodDependency reports whether a dependency is a parameter of an
+// injection method rather than a field
+func isMethodDependency(dependency *Dependency) bool {
+	return strings.HasSuffix(dependency.Field, ")")
+}
+
+// rewireFieldUnsafe resolves a recorded autowired field again and sets it,
+// without locking (assumes caller holds lock)
+func (c *Container) rewireFieldUnsafe(consumer *ComponentInfo, dependency *Dependency) error {
+	field := reflect.ValueOf(consumer.Instance)
+	for _, name := range strings.Split(dependency.Field, ".") {
+		if field.Kind() == reflect.Ptr {
+			field = field.Elem()
+		}
+		field = field.FieldByName(name)
+	}
+	field, ok := c.settableField(field)
+	if !ok {
+		return fmt.Errorf("field is not settable")
+	}
+
+	var value interface{}
+	var sources []*ComponentInfo
+	var err error
+	if isLabelQualifier(dependency.Qualifier) {
+		var selectors []labelSelector
+		if selectors, err = parseLabelSelectors(dependency.Qualifier); err == nil {
+			value, sources, err = c.resolveByLabelUnsafe(dependency.Type, selectors)
+		}
+	} else {
+		var source *ComponentInfo
+		value, source, err = c.resolveDependencyUnsafe(dependency.Type, dependency.Qualifier, consumer)
+		sources = []*ComponentInfo{source}
+	}
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return nil
+	}
+
+	field.Set(reflect.ValueOf(value))
+	dependency.Components = dependency.Components[:0]
+	for _, source := range sources {
+		dependency.Components = append(dependency.Components, source.Name)
+	}
+	return nil
+}
+
+// sameInstance reports whether two instances are identical without panicking
+// on uncomparable types
+func sameInstance(a, b interface{}) bool {
+	t := reflect.TypeOf(a)
+	if t != reflect.TypeOf(b) || !t.Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// Start runs startup phase in descending priority order (higher priority first),
+// then launches every Runnable component in its own goroutine. If a component
+// fails to start, the components started before it are stopped again in
+// reverse order.
 func (c *Container) Start(ctx context.Context) error {
 	c.lifecycleMu.Lock()
 	defer c.lifecycleMu.Unlock()
@@ -297,22 +684,48 @@ func (c *Container) Start(ctx context.Context) error {
 		return fmt.Errorf("container already started")
 	}
 	c.sealed = true
+	c.setState(StateStarting)
 
 	components := c.getSortedComponents(false) // descending order
 
-	for _, info := range components {
-		if startable, ok := info.Instance.(Startable); ok {
-			if err := startable.Start(ctx); err != nil {
-				return fmt.Errorf("startup failed for '%s': %w", info.Name, err)
-			}
+	var startedMu sync.Mutex
+	var started []*ComponentInfo
+	err := c.runPhase(ctx, components, func(ctx context.Context, info *ComponentInfo) error {
+		if err := c.startComponent(ctx, info); err != nil {
+			return err
+		}
+		startedMu.Lock()
+		started = append(started, info)
+		startedMu.Unlock()
+		return nil
+	})
+	if err != nil {
+		if rollbackErr := c.rollback(context.WithoutCancel(ctx), started); rollbackErr != nil {
+			err = errors.Join(err, rollbackErr)
 		}
+		c.setState(StateFailed)
+		return err
 	}
 
+	c.startRunners(ctx, components)
 	c.started = true
+	c.setState(StateRunning)
 	return nil
 }
 
-// Stop runs shutdown phase in ascending priority order (lower priority first)
+// startComponent calls Start on a component if it is Startable
+func (c *Container) startComponent(ctx context.Context, info *ComponentInfo) error {
+	if startable, ok := info.Instance.(Startable); ok {
+		if err := c.callHook(info, PhaseStart, func() error { return startable.Start(ctx) }); err != nil {
+			return fmt.Errorf("startup failed for '%s': %w", info.Name, err)
+		}
+	}
+	return nil
+}
+
+// Stop runs shutdown phase in ascending priority order (lower priority first).
+// The runner of a Runnable component is cancelled and awaited before the
+// component itself is stopped.
 func (c *Container) Stop(ctx context.Context) error {
 	c.lifecycleMu.Lock()
 	defer c.lifecycleMu.Unlock()
@@ -320,23 +733,96 @@ func (c *Container) Stop(ctx context.Context) error {
 	if !c.started {
 		return nil
 	}
+	c.setState(StateStopping)
 
 	components := c.getSortedComponents(true) // ascending order
 
+	var pending []string
+	for _, info := range components {
+		_, stoppable := info.Instance.(Stoppable)
+		_, running := c.runners[info]
+		if stoppable || running {
+			pending = append(pending, info.Name)
+		}
+	}
+	c.setStopPending(pending)
+
 	var lastErr error
 	for _, info := range components {
-		if stoppable, ok := info.Instance.(Stoppable); ok {
-			if err := stoppable.Stop(ctx); err != nil {
-				lastErr = fmt.Errorf("shutdown failed for '%s': %w", info.Name, err)
-			}
+		if err := c.stopComponent(ctx, info); err != nil {
+			lastErr = err
 		}
+		c.markStopped(info.Name)
 	}
 
 	c.started = false
+	c.setState(StateStopped)
+	return lastErr
+}
+
+// stopComponent cancels the runner of a component, then calls Stop if it is
+// Stoppable, returning the last error
+func (c *Container) stopComponent(ctx context.Context, info *ComponentInfo) error {
+	var lastErr error
+	if err := c.stopRunner(info); err != nil {
+		lastErr = fmt.Errorf("shutdown failed for '%s': %w", info.Name, err)
+	}
+	if stoppable, ok := info.Instance.(Stoppable); ok {
+		if err := c.callHook(info, PhaseStop, func() error { return stoppable.Stop(ctx) }); err != nil {
+			lastErr = fmt.Errorf("shutdown failed for '%s': %w", info.Name, err)
+		}
+	}
 	return lastErr
 }
 
-// getSortedComponents returns components sorted by priority
+// rollback stops the components started before a startup failure in reverse
+// start order, continuing past failures and returning them joined
+func (c *Container) rollback(ctx context.Context, started []*ComponentInfo) error {
+	if len(started) > 0 {
+		c.log().Warnf("ginject: startup failed, stopping %d started components", len(started))
+	}
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		if err := c.stopComponent(ctx, started[i]); err != nil {
+			c.log().Errorf("ginject: rollback: %v", err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// setStopPending records the components that still have to be stopped
+func (c *Container) setStopPending(names []string) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.stopPending = names
+}
+
+// markStopped removes a component from the components still to be stopped
+func (c *Container) markStopped(name string) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	for i, pending := range c.stopPending {
+		if pending == name {
+			c.stopPending = append(c.stopPending[:i:i], c.stopPending[i+1:]...)
+			return
+		}
+	}
+}
+
+// pendingStop returns the components a running Stop has not stopped yet
+func (c *Container) pendingStop() []string {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	pending := make([]string, len(c.stopPending))
+	copy(pending, c.stopPending)
+	return pending
+}
+
+// getSortedComponents returns components sorted by priority, moving each
+// component after the components it explicitly depends on. Components of
+// equal priority keep their registration order, and the ascending order is
+// the exact reverse of the descending one.
 func (c *Container) getSortedComponents(ascending bool) []*ComponentInfo {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -344,12 +830,15 @@ func (c *Container) getSortedComponents(ascending bool) []*ComponentInfo {
 	components := make([]*ComponentInfo, len(c.components))
 	copy(components, c.components)
 
-	sort.Slice(components, func(i, j int) bool {
-		if ascending {
-			return components[i].Priority < components[j].Priority
-		}
+	sort.SliceStable(components, func(i, j int) bool {
 		return components[i].Priority > components[j].Priority
 	})
+	components = orderByDependsOn(components)
+	if ascending {
+		for i, j := 0, len(components)-1; i < j; i, j = i+1, j-1 {
+			components[i], components[j] = components[j], components[i]
+		}
+	}
 
 	return components
 }
@@ -380,9 +869,20 @@ func (c *Container) validateTypeRegistrations() error {
 			continue
 		}
 
-		// Multiple components - find primary
+		// Fallbacks lose to any other component exporting the type
+		candidates := preferNonFallback(components)
+		if len(candidates) == 1 {
+			c.componentsByType[exportedType] = &ExportedComponentsInfo{
+				ExportedType: exportedType,
+				Primary:      candidates[0],
+				Components:   components,
+			}
+			continue
+		}
+
+		// Multiple candidates - find primary
 		var primaryComponents []*ComponentInfo
-		for _, comp := range components {
+		for _, comp := range candidates {
 			if comp.IsPrimary {
 				primaryComponents = append(primaryComponents, comp)
 			}
@@ -390,8 +890,8 @@ func (c *Container) validateTypeRegistrations() error {
 
 		if len(primaryComponents) == 0 {
 			// No primary - ambiguous
-			names := make([]string, len(components))
-			for i, comp := range components {
+			names := make([]string, len(candidates))
+			for i, comp := range candidates {
 				names[i] = comp.Name
 			}
 			return fmt.Errorf("ambiguous components for type '%s': %v (mark one as Primary())",
@@ -416,34 +916,119 @@ func (c *Container) validateTypeRegistrations() error {
 		}
 	}
 
-	return nil
+	return c.applyDecoratorsUnsafe()
+}
+
+// preferNonFallback returns the components not marked as fallback, or all of
+// them when every component is a fallback
+func preferNonFallback(components []*ComponentInfo) []*ComponentInfo {
+	var preferred []*ComponentInfo
+	for _, info := range components {
+		if !info.IsFallback {
+			preferred = append(preferred, info)
+		}
+	}
+	if len(preferred) == 0 {
+		return components
+	}
+	return preferred
 }
 
-// Run executes the complete lifecycle: register pending → validate → inject → init → start
+// Run executes the complete lifecycle: register pending → validate → inject → init → start.
+// A container created with WithDryRunMode performs a DryRun instead.
 func (c *Container) Run(ctx context.Context) error {
+	if c.dryRun {
+		return c.DryRun()
+	}
+	if err := c.markRun(); err != nil {
+		return err
+	}
+	c.setState(StateStarting)
+
+	if err := c.run(ctx); err != nil {
+		c.setState(StateFailed)
+		return err
+	}
+	return nil
+}
+
+// markRun records a run, refusing a second run of the default container
+func (c *Container) markRun() error {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	if c.ran && c == Default() {
+		return ErrAlreadyRun
+	}
+	c.ran = true
+	return nil
+}
+
+// run performs the steps of Run
+func (c *Container) run(ctx context.Context) error {
+	if err := c.wire(); err != nil {
+		return err
+	}
+
+	if err := c.Initialize(ctx); err != nil {
+		return fmt.Errorf("initialization failed: %w", err)
+	}
+
+	if err := c.Start(ctx); err != nil {
+		return fmt.Errorf("startup failed: %w", err)
+	}
+
+	return nil
+}
+
+// wireOnly runs the container as wire without any lifecycle call, leaving it
+// stopped, or failed on a wiring error
+func (c *Container) wireOnly() error {
+	if err := c.markRun(); err != nil {
+		return err
+	}
+	c.setState(StateStarting)
+
+	if err := c.wire(); err != nil {
+		c.setState(StateFailed)
+		return err
+	}
+	c.setState(StateStopped)
+	return nil
+}
+
+// wire registers pending builders, validates the registrations, and injects
+// dependencies without calling any lifecycle method
+func (c *Container) wire() error {
 	// First register all pending builders
 	if err := c.registerPendingBuilders(); err != nil {
 		return fmt.Errorf("registration failed: %w", err)
 	}
+	c.resetReplacements()
 
 	// Then validate all type registrations
 	if err := c.validateTypeRegistrations(); err != nil {
 		return fmt.Errorf("type validation failed: %w", err)
 	}
 
+	if err := c.validateDependsOn(); err != nil {
+		return fmt.Errorf("dependency validation failed: %w", err)
+	}
+
 	if err := c.InjectDependencies(); err != nil {
 		return fmt.Errorf("dependency injection failed: %w", err)
 	}
 
-	if err := c.Initialize(ctx); err != nil {
-		return fmt.Errorf("initialization failed: %w", err)
-	}
+	return nil
+}
 
-	if err := c.Start(ctx); err != nil {
-		return fmt.Errorf("startup failed: %w", err)
+// resetReplacements discards the post-processor replacements of a previous
+// run, so a new run starts from the registered instances
+func (c *Container) resetReplacements() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, info := range c.components {
+		info.replacement = nil
 	}
-
-	return nil
 }
 
 // registerPendingBuilders registers all pending ObjectBuilders
@@ -454,10 +1039,26 @@ func (c *Container) registerPendingBuilders() error {
 	c.mu.Lock()
 	pendingBuilders := c.pendingBuilders
 	c.pendingBuilders = nil
+	decoratorErr := c.decoratorErr
 	c.mu.Unlock()
 	c.lifecycleMu.Unlock()
 
+	if decoratorErr != nil {
+		return decoratorErr
+	}
+
+	// Overrides are registered last so they can replace any other registration
+	var overrides []*ObjectBuilder
 	for _, builder := range pendingBuilders {
+		if builder.isReplacement() {
+			overrides = append(overrides, builder)
+			continue
+		}
+		if err := builder.register(); err != nil {
+			return err
+		}
+	}
+	for _, builder := range overrides {
 		if err := builder.register(); err != nil {
 			return err
 		}
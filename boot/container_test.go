@@ -2,6 +2,7 @@ package boot
 
 import (
 	"context"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -116,3 +117,281 @@ func TestContainerObjectPanicsAfterRun(t *testing.T) {
 
 	container.Object(&containerRunConsoleLogger{})
 }
+
+type postProcessorGreeter interface {
+	Greet() string
+}
+
+type postProcessorPlainGreeter struct {
+	initialized bool
+}
+
+func (g *postProcessorPlainGreeter) Init(context.Context) error {
+	g.initialized = true
+	return nil
+}
+
+func (g *postProcessorPlainGreeter) Greet() string {
+	return "hello"
+}
+
+type postProcessorLoudGreeter struct {
+	inner postProcessorGreeter
+}
+
+func (g *postProcessorLoudGreeter) Greet() string {
+	return strings.ToUpper(g.inner.Greet())
+}
+
+type postProcessorConsumer struct {
+	Greeter postProcessorGreeter `autowire:""`
+	greeted string
+}
+
+func (c *postProcessorConsumer) Init(context.Context) error {
+	c.greeted = c.Greeter.Greet()
+	return nil
+}
+
+type wrappingPostProcessor struct {
+	before []string
+	after  []string
+}
+
+func (p *wrappingPostProcessor) BeforeInit(info *ComponentInfo, instance interface{}) (interface{}, error) {
+	p.before = append(p.before, info.Name)
+	return instance, nil
+}
+
+func (p *wrappingPostProcessor) AfterInit(info *ComponentInfo, instance interface{}) (interface{}, error) {
+	p.after = append(p.after, info.Name)
+	if greeter, ok := instance.(*postProcessorPlainGreeter); ok {
+		return &postProcessorLoudGreeter{inner: greeter}, nil
+	}
+	return instance, nil
+}
+
+func TestContainerRunInvokesPostProcessorsAroundInit(t *testing.T) {
+	container := NewContainer()
+	processor := &wrappingPostProcessor{}
+	greeter := &postProcessorPlainGreeter{}
+	consumer := &postProcessorConsumer{}
+
+	container.Object(processor).Priority(-10)
+	container.Object(greeter).Export((*postProcessorGreeter)(nil)).Name("greeter").Priority(10)
+	container.Object(consumer).Name("consumer")
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+
+	expected := []string{"greeter", "consumer"}
+	if !reflect.DeepEqual(processor.before, expected) || !reflect.DeepEqual(processor.after, expected) {
+		t.Fatalf("expected post-processor calls for %v, got before=%v after=%v", expected, processor.before, processor.after)
+	}
+	if !greeter.initialized {
+		t.Fatal("expected original greeter to be initialized")
+	}
+	if consumer.greeted != "HELLO" {
+		t.Fatalf("expected consumer to be rewired to the wrapped greeter before Init, got %q", consumer.greeted)
+	}
+	component, err := container.GetByName("greeter")
+	if err != nil {
+		t.Fatalf("expected greeter to resolve by name, got %v", err)
+	}
+	if _, ok := component.(*postProcessorLoudGreeter); !ok {
+		t.Fatalf("expected registered component to be replaced, got %T", component)
+	}
+}
+
+type postProcessorLifecycleGreeter struct {
+	postProcessorPlainGreeter
+	started bool
+	stopped bool
+}
+
+func (g *postProcessorLifecycleGreeter) Start(context.Context) error {
+	g.started = true
+	return nil
+}
+
+func (g *postProcessorLifecycleGreeter) Stop(context.Context) error {
+	g.stopped = true
+	return nil
+}
+
+type wrappingLifecyclePostProcessor struct{}
+
+func (p *wrappingLifecyclePostProcessor) BeforeInit(info *ComponentInfo, instance interface{}) (interface{}, error) {
+	return instance, nil
+}
+
+func (p *wrappingLifecyclePostProcessor) AfterInit(info *ComponentInfo, instance interface{}) (interface{}, error) {
+	if greeter, ok := instance.(*postProcessorLifecycleGreeter); ok {
+		return &postProcessorLoudGreeter{inner: greeter}, nil
+	}
+	return instance, nil
+}
+
+func TestContainerPostProcessorReplacementKeepsLifecycleOnOriginal(t *testing.T) {
+	container := NewContainer()
+	greeter := &postProcessorLifecycleGreeter{}
+	early := &postProcessorConsumer{}
+	late := &postProcessorConsumer{}
+	decorations := 0
+
+	container.Object(&wrappingLifecyclePostProcessor{})
+	container.Object(greeter).Export((*postProcessorGreeter)(nil)).Name("greeter").Priority(10)
+	container.Object(early).Name("early").Priority(20)
+	container.Object(late).Name("late").Primary()
+	container.Object(&containerRunConsoleLogger{}).Export((*containerRunLogger)(nil))
+	container.Decorate((*containerRunLogger)(nil), func(inner containerRunLogger) containerRunLogger {
+		decorations++
+		return inner
+	})
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	if err := container.Stop(context.Background()); err != nil {
+		t.Fatalf("expected container to stop, got %v", err)
+	}
+
+	if !greeter.initialized || !greeter.started || !greeter.stopped {
+		t.Fatalf("expected lifecycle calls on the original component, got %+v", greeter)
+	}
+	if late.greeted != "HELLO" {
+		t.Fatalf("expected later consumer to receive the replacement, got %q", late.greeted)
+	}
+	if early.greeted != "hello" || early.Greeter != postProcessorGreeter(greeter) {
+		t.Fatalf("expected initialized consumer to keep its dependency, got %q", early.greeted)
+	}
+	if decorations != 1 {
+		t.Fatalf("expected decorators to run once, got %d", decorations)
+	}
+}
+
+type postProcessorMethodConsumer struct {
+	greeter postProcessorGreeter
+}
+
+func (c *postProcessorMethodConsumer) Inject(greeter postProcessorGreeter) {
+	c.greeter = greeter
+}
+
+func TestContainerPostProcessorReplacementSkipsInjectMethodParameters(t *testing.T) {
+	container := NewContainer()
+	greeter := &postProcessorLifecycleGreeter{}
+	consumer := &postProcessorMethodConsumer{}
+
+	container.Object(&wrappingLifecyclePostProcessor{})
+	container.Object(greeter).Export((*postProcessorGreeter)(nil)).Name("greeter").Priority(10)
+	container.Object(consumer).Name("consumer").Inject()
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	defer container.Stop(context.Background())
+
+	if consumer.greeter != postProcessorGreeter(greeter) {
+		t.Fatalf("expected Inject method parameter to keep the original, got %T", consumer.greeter)
+	}
+}
+
+type rejectingPostProcessor struct{}
+
+func (p *rejectingPostProcessor) BeforeInit(info *ComponentInfo, instance interface{}) (interface{}, error) {
+	return instance, nil
+}
+
+func (p *rejectingPostProcessor) AfterInit(info *ComponentInfo, instance interface{}) (interface{}, error) {
+	return &containerRunConsoleLogger{}, nil
+}
+
+type orderedPostProcessor struct {
+	recorder *dependsOnRecorder
+}
+
+func (p *orderedPostProcessor) Init(context.Context) error {
+	p.recorder.record("init processor")
+	return nil
+}
+
+func (p *orderedPostProcessor) BeforeInit(info *ComponentInfo, instance interface{}) (interface{}, error) {
+	p.recorder.record("process " + info.Name)
+	return instance, nil
+}
+
+func (p *orderedPostProcessor) AfterInit(info *ComponentInfo, instance interface{}) (interface{}, error) {
+	return instance, nil
+}
+
+func TestContainerInitializesPostProcessorAfterItsDependencies(t *testing.T) {
+	recorder := &dependsOnRecorder{}
+	container := NewContainer()
+	container.Object(&dependsOnComponent{name: "registry", recorder: recorder})
+	container.Object(&orderedPostProcessor{recorder: recorder}).Name("processor").Priority(10).DependsOn("registry")
+	container.Object(&secondDependsOnComponent{dependsOnComponent{name: "app", recorder: recorder}}).Priority(5)
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+
+	expected := "init registry,init processor,process app,init app,start app,start registry"
+	if got := strings.Join(recorder.events, ","); got != expected {
+		t.Fatalf("expected events %s, got %s", expected, got)
+	}
+}
+
+func TestContainerRunRejectsPostProcessorReplacementOfWrongType(t *testing.T) {
+	container := NewContainer()
+	container.Object(&rejectingPostProcessor{})
+	container.Object(&postProcessorPlainGreeter{}).Export((*postProcessorGreeter)(nil))
+
+	err := container.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "cannot be exported as") {
+		t.Fatalf("expected replacement type error, got %v", err)
+	}
+}
+
+type unexportedFieldApp struct {
+	logger containerRunLogger `autowire:""`
+}
+
+type unexportedNestedDependencies struct {
+	logger containerRunLogger `autowire:""`
+}
+
+type unexportedNestedApp struct {
+	deps unexportedNestedDependencies
+}
+
+func TestContainerRunFailsOnUnexportedAutowireField(t *testing.T) {
+	container := NewContainer()
+	container.Object(&containerRunConsoleLogger{}).Export((*containerRunLogger)(nil))
+	container.Object(&unexportedFieldApp{})
+
+	err := container.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "cannot autowire unexported field logger") {
+		t.Fatalf("expected unexported field error, got %v", err)
+	}
+}
+
+func TestContainerRunInjectsUnexportedFieldsWhenEnabled(t *testing.T) {
+	container := NewContainer(WithUnexportedFieldInjection())
+	app := &unexportedFieldApp{}
+	nested := &unexportedNestedApp{}
+	container.Object(&containerRunConsoleLogger{}).Export((*containerRunLogger)(nil))
+	container.Object(app)
+	container.Object(nested)
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected unexported fields to be injected, got %v", err)
+	}
+	if app.logger == nil {
+		t.Fatal("expected unexported field to be injected")
+	}
+	if nested.deps.logger == nil {
+		t.Fatal("expected unexported nested struct to be injected")
+	}
+}
@@ -0,0 +1,91 @@
+package boot
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Decorate registers a decorator for an exported type. The decorator must be a
+// function of the form func(inner T) T. Decorators for the same type form a
+// chain applied in registration order, so the first one registered wraps the
+// component directly. Type-based lookups and autowiring receive the decorated
+// value, while GetByName still returns the raw component.
+func (c *Container) Decorate(typePtr interface{}, decorator interface{}) {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	if c.sealed {
+		panic("cannot register decorator after container has started")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	decoratedType, fn, err := parseDecorator(typePtr, decorator)
+	if err != nil {
+		if c.decoratorErr == nil {
+			c.decoratorErr = err
+		}
+		return
+	}
+	c.decorators[decoratedType] = append(c.decorators[decoratedType], fn)
+}
+
+// parseDecorator validates a decorator function against the decorated type
+func parseDecorator(typePtr interface{}, decorator interface{}) (reflect.Type, reflect.Value, error) {
+	t := reflect.TypeOf(typePtr)
+	if t == nil {
+		return nil, reflect.Value{}, fmt.Errorf("cannot decorate nil type")
+	}
+	if t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Interface {
+		t = t.Elem()
+	}
+
+	fn := reflect.ValueOf(decorator)
+	if fn.Kind() != reflect.Func || fn.IsNil() {
+		return nil, reflect.Value{}, fmt.Errorf("decorator for type %s must be a function, got %T", t, decorator)
+	}
+	fnType := fn.Type()
+	if fnType.NumIn() != 1 || fnType.NumOut() != 1 || fnType.In(0) != t || fnType.Out(0) != t {
+		return nil, reflect.Value{}, fmt.Errorf("decorator for type %s must have signature func(%s) %s, got %s",
+			t, t, t, fnType)
+	}
+	return t, fn, nil
+}
+
+// applyDecoratorsUnsafe decorates every component of each decorated exported
+// type without locking (assumes caller holds lock)
+func (c *Container) applyDecoratorsUnsafe() error {
+	for decoratedType, chain := range c.decorators {
+		exported, exists := c.componentsByType[decoratedType]
+		if !exists {
+			return fmt.Errorf("decorator registered for type '%s' but no component exports it", decoratedType)
+		}
+
+		exported.decorated = make(map[*ComponentInfo]interface{}, len(exported.Components))
+		for _, info := range exported.Components {
+			value := reflect.ValueOf(info.Instance).Convert(decoratedType)
+			for _, fn := range chain {
+				if err := c.recoverPanic(info, PhaseDecorate, func() error {
+					value = fn.Call([]reflect.Value{value})[0]
+					return nil
+				}); err != nil {
+					return fmt.Errorf("decorator for type '%s' failed: %w", decoratedType, err)
+				}
+				if value.Kind() == reflect.Interface && value.IsNil() {
+					return fmt.Errorf("decorator for type '%s' returned nil for component '%s'", decoratedType, info.Name)
+				}
+			}
+			exported.decorated[info] = value.Interface()
+		}
+	}
+	return nil
+}
+
+// instance returns the value consumers of the exported type receive for a
+// component, which is the decorated value when decorators are registered
+func (e *ExportedComponentsInfo) instance(info *ComponentInfo) interface{} {
+	if decorated, exists := e.decorated[info]; exists {
+		return decorated
+	}
+	return info.exposed(e.ExportedType)
+}
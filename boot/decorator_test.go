@@ -0,0 +1,100 @@
+package boot
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type decoratorGreeter interface {
+	Greet() string
+}
+
+type decoratorPlainGreeter struct{}
+
+func (g *decoratorPlainGreeter) Greet() string {
+	return "hello"
+}
+
+type decoratorSuffixGreeter struct {
+	inner  decoratorGreeter
+	suffix string
+}
+
+func (g *decoratorSuffixGreeter) Greet() string {
+	return g.inner.Greet() + g.suffix
+}
+
+type decoratorConsumer struct {
+	Greeter decoratorGreeter `autowire:""`
+	Named   decoratorGreeter `autowire:"greeter"`
+}
+
+func TestContainerDecorateAppliesChainToTypeLookups(t *testing.T) {
+	container := NewContainer()
+	raw := &decoratorPlainGreeter{}
+	consumer := &decoratorConsumer{}
+
+	container.Object(raw).Export((*decoratorGreeter)(nil)).Name("greeter")
+	container.Object(consumer)
+	container.Decorate((*decoratorGreeter)(nil), func(inner decoratorGreeter) decoratorGreeter {
+		return &decoratorSuffixGreeter{inner: inner, suffix: " world"}
+	})
+	container.Decorate((*decoratorGreeter)(nil), func(inner decoratorGreeter) decoratorGreeter {
+		return &decoratorSuffixGreeter{inner: inner, suffix: "!"}
+	})
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+
+	if got := consumer.Greeter.Greet(); got != "hello world!" {
+		t.Fatalf("expected autowired greeter to be decorated in order, got %q", got)
+	}
+	if got := consumer.Named.Greet(); got != "hello world!" {
+		t.Fatalf("expected qualified greeter to be decorated, got %q", got)
+	}
+
+	byType, err := container.GetByType(reflect.TypeOf((*decoratorGreeter)(nil)).Elem())
+	if err != nil {
+		t.Fatalf("expected greeter to resolve by type, got %v", err)
+	}
+	if byType != consumer.Greeter {
+		t.Fatal("expected GetByType to return the same decorated instance as autowiring")
+	}
+
+	byName, err := container.GetByName("greeter")
+	if err != nil {
+		t.Fatalf("expected greeter to resolve by name, got %v", err)
+	}
+	if byName != raw {
+		t.Fatalf("expected GetByName to return the raw component, got %T", byName)
+	}
+}
+
+func TestContainerDecorateRejectsInvalidSignature(t *testing.T) {
+	container := NewContainer()
+	container.Object(&decoratorPlainGreeter{}).Export((*decoratorGreeter)(nil))
+	container.Decorate((*decoratorGreeter)(nil), func(inner *decoratorPlainGreeter) decoratorGreeter {
+		return inner
+	})
+
+	err := container.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "must have signature") {
+		t.Fatalf("expected decorator signature error, got %v", err)
+	}
+}
+
+func TestContainerDecorateRejectsTypeWithoutExporter(t *testing.T) {
+	container := NewContainer()
+	container.Object(&decoratorPlainGreeter{})
+	container.Decorate((*decoratorGreeter)(nil), func(inner decoratorGreeter) decoratorGreeter {
+		return inner
+	})
+
+	err := container.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "no component exports it") {
+		t.Fatalf("expected missing exporter error, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package boot
+
+import (
+	"fmt"
+	"strings"
+)
+
+// validateDependsOn checks that every DependsOn name refers to a registered
+// component and that the explicit ordering edges contain no cycle
+func (c *Container) validateDependsOn() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, info := range c.components {
+		for _, name := range info.DependsOn {
+			if name == info.Name {
+				return fmt.Errorf("component '%s' depends on itself", info.Name)
+			}
+			if _, exists := c.componentByName[name]; !exists {
+				return fmt.Errorf("component '%s' depends on unknown component '%s'", info.Name, name)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	marks := make(map[*ComponentInfo]int, len(c.components))
+	var path []string
+	var visit func(info *ComponentInfo) error
+	visit = func(info *ComponentInfo) error {
+		switch marks[info] {
+		case visited:
+			return nil
+		case visiting:
+			for i, name := range path {
+				if name == info.Name {
+					cycle := append(path[i:len(path):len(path)], info.Name)
+					return fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> "))
+				}
+			}
+		}
+		marks[info] = visiting
+		path = append(path, info.Name)
+		for _, name := range info.DependsOn {
+			if err := visit(c.componentByName[name]); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		marks[info] = visited
+		return nil
+	}
+	for _, info := range c.components {
+		if err := visit(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// orderByDependsOn reorders components so that every component comes after
+// the components it explicitly depends on, otherwise keeping the given order.
+// Components left on a cycle keep their relative order at the end.
+func orderByDependsOn(components []*ComponentInfo) []*ComponentInfo {
+	remaining := make(map[string]bool, len(components))
+	hasEdges := false
+	for _, info := range components {
+		remaining[info.Name] = true
+		hasEdges = hasEdges || len(info.DependsOn) > 0
+	}
+	if !hasEdges {
+		return components
+	}
+
+	ready := func(info *ComponentInfo) bool {
+		for _, name := range info.DependsOn {
+			if remaining[name] {
+				return false
+			}
+		}
+		return true
+	}
+
+	ordered := make([]*ComponentInfo, 0, len(components))
+	pending := components
+	for len(pending) > 0 {
+		next := -1
+		for i, info := range pending {
+			if ready(info) {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			// A cycle, rejected by validateDependsOn when running
+			return append(ordered, pending...)
+		}
+		info := pending[next]
+		ordered = append(ordered, info)
+		delete(remaining, info.Name)
+		pending = append(pending[:next:next], pending[next+1:]...)
+	}
+	return ordered
+}
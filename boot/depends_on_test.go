@@ -0,0 +1,126 @@
+package boot
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type dependsOnRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *dependsOnRecorder) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+type dependsOnComponent struct {
+	name     string
+	recorder *dependsOnRecorder
+}
+
+func (c *dependsOnComponent) Name() string {
+	return c.name
+}
+
+func (c *dependsOnComponent) Init(context.Context) error {
+	c.recorder.record("init " + c.name)
+	return nil
+}
+
+func (c *dependsOnComponent) Start(context.Context) error {
+	c.recorder.record("start " + c.name)
+	return nil
+}
+
+func (c *dependsOnComponent) Stop(context.Context) error {
+	c.recorder.record("stop " + c.name)
+	return nil
+}
+
+type secondDependsOnComponent struct {
+	dependsOnComponent
+}
+
+type thirdDependsOnComponent struct {
+	dependsOnComponent
+}
+
+func TestContainerDependsOnOrdersLifecycleAcrossPriorities(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		recorder := &dependsOnRecorder{}
+		var opts []ContainerOption
+		if parallel {
+			opts = append(opts, WithParallelLifecycle(0))
+		}
+		container := NewContainer(opts...)
+		container.Object(&dependsOnComponent{name: "server", recorder: recorder}).Priority(10).DependsOn("migrator")
+		container.Object(&secondDependsOnComponent{dependsOnComponent{name: "cache", recorder: recorder}}).Priority(10)
+		container.Object(&thirdDependsOnComponent{dependsOnComponent{name: "migrator", recorder: recorder}})
+
+		if err := container.Run(context.Background()); err != nil {
+			t.Fatalf("expected container to run, got %v", err)
+		}
+		if err := container.Stop(context.Background()); err != nil {
+			t.Fatalf("expected container to stop, got %v", err)
+		}
+
+		expected := "init cache,init migrator,init server,start cache,start migrator,start server," +
+			"stop server,stop migrator,stop cache"
+		if got := strings.Join(recorder.events, ","); got != expected {
+			t.Fatalf("parallel=%v: expected events %s, got %s", parallel, expected, got)
+		}
+	}
+}
+
+func TestContainerRunValidatesDependsOn(t *testing.T) {
+	tests := []struct {
+		name     string
+		register func(c *Container, recorder *dependsOnRecorder)
+		expected string
+	}{
+		{
+			name: "unknown",
+			register: func(c *Container, recorder *dependsOnRecorder) {
+				c.Object(&dependsOnComponent{name: "server", recorder: recorder}).DependsOn("migrator")
+			},
+			expected: "component 'server' depends on unknown component 'migrator'",
+		},
+		{
+			name: "self",
+			register: func(c *Container, recorder *dependsOnRecorder) {
+				c.Object(&dependsOnComponent{name: "server", recorder: recorder}).DependsOn("server")
+			},
+			expected: "component 'server' depends on itself",
+		},
+		{
+			name: "cycle",
+			register: func(c *Container, recorder *dependsOnRecorder) {
+				c.Object(&dependsOnComponent{name: "a", recorder: recorder}).DependsOn("b")
+				c.Object(&secondDependsOnComponent{dependsOnComponent{name: "b", recorder: recorder}}).DependsOn("c")
+				c.Object(&thirdDependsOnComponent{dependsOnComponent{name: "c", recorder: recorder}}).DependsOn("a")
+			},
+			expected: "dependency cycle: a -> b -> c -> a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &dependsOnRecorder{}
+			container := NewContainer()
+			tt.register(container, recorder)
+
+			err := container.Run(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Fatalf("expected error containing %q, got %v", tt.expected, err)
+			}
+			if len(recorder.events) != 0 {
+				t.Fatalf("expected no lifecycle calls, got %v", recorder.events)
+			}
+		})
+	}
+}
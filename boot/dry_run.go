@@ -0,0 +1,60 @@
+package boot
+
+import "strings"
+
+// ModeDryRun makes Run and RunApplication wire the container and log a summary
+// without calling Init or Start (see Container.DryRun). Container.Run ignores it;
+// use WithDryRunMode to make a container dry run on its own.
+const ModeDryRun = "dry-run"
+
+// DryRun registers pending builders, validates the registrations, and
+// injects dependencies like Run, then logs a summary of the wiring through
+// the container logger instead of calling Init and Start. It returns the
+// error Run would have failed with before initialization. Like Run, it counts
+// as the single run of the default container.
+func (c *Container) DryRun() error {
+	if err := c.wireOnly(); err != nil {
+		if err != ErrAlreadyRun {
+			c.log().Errorf("ginject: dry run failed: %v", err)
+		}
+		return err
+	}
+	c.logDryRunSummary()
+	return nil
+}
+
+// logDryRunSummary logs the wired components in start order with the
+// lifecycle calls a real run would make
+func (c *Container) logDryRunSummary() {
+	components := c.getSortedComponents(false)
+
+	var inits, starts int
+	for _, info := range components {
+		if _, ok := info.Instance.(Initializable); ok {
+			inits++
+		}
+		if _, ok := info.Instance.(Startable); ok {
+			starts++
+		}
+	}
+	c.log().Infof("ginject: dry run: %d components wired, skipped %d Init and %d Start calls", len(components), inits, starts)
+
+	for i, info := range components {
+		var edges []string
+		for _, dependency := range info.Dependencies {
+			if len(dependency.Components) == 0 {
+				continue
+			}
+			edges = append(edges, dependency.Field+" <- "+strings.Join(dependency.Components, ", "))
+		}
+		if len(info.DependsOn) > 0 {
+			edges = append(edges, "depends on "+strings.Join(info.DependsOn, ", "))
+		}
+
+		line := ""
+		if len(edges) > 0 {
+			line = ": " + strings.Join(edges, "; ")
+		}
+		c.log().Infof("ginject: dry run: %d. %s (%s)%s", i+1, info.Name, info.InstanceType, line)
+	}
+}
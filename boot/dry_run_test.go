@@ -0,0 +1,163 @@
+package boot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContainerDryRunWiresWithoutLifecycleCalls(t *testing.T) {
+	logger := &capturingLogger{}
+	recorder := &panicRecorder{}
+	container := NewContainer(WithLogger(logger))
+	app := &containerRunApp{}
+	container.Object(app).Name("app")
+	container.Object(&recordingLifecycleComponent{name: "worker", recorder: recorder}).Priority(10)
+	container.Object(&containerRunConsoleLogger{}).Name("console").Export((*containerRunLogger)(nil))
+
+	if err := container.DryRun(); err != nil {
+		t.Fatalf("expected dry run to succeed, got %v", err)
+	}
+	if app.Logger == nil {
+		t.Fatal("expected dependencies to be injected")
+	}
+	if app.Started || len(recorder.events) != 0 {
+		t.Fatalf("expected no lifecycle calls, got %v", recorder.events)
+	}
+	if state := container.State(); state != StateStopped {
+		t.Fatalf("expected state stopped, got %s", state)
+	}
+
+	expected := []string{
+		"ginject: dry run: 3 components wired, skipped 0 Init and 2 Start calls",
+		"ginject: dry run: 1. worker (*boot.recordingLifecycleComponent)",
+		"ginject: dry run: 2. app (*boot.containerRunApp): Logger <- console",
+		"ginject: dry run: 3. console (*boot.containerRunConsoleLogger)",
+	}
+	if strings.Join(logger.info, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("expected summary %q, got %q", expected, logger.info)
+	}
+
+	if err := container.Stop(context.Background()); err != nil || len(recorder.events) != 0 {
+		t.Fatalf("expected Stop to be a no-op after a dry run, got %v %v", err, recorder.events)
+	}
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected dry-run container to run afterwards, got %v", err)
+	}
+	if err := container.Stop(context.Background()); err != nil || len(recorder.events) != 2 {
+		t.Fatalf("expected a real run after the dry run, got %v %v", err, recorder.events)
+	}
+}
+
+func TestContainerDryRunReportsWiringError(t *testing.T) {
+	logger := &capturingLogger{}
+	container := NewContainer(WithLogger(logger))
+	container.Object(&containerRunApp{}).Name("app")
+
+	err := container.DryRun()
+	if err == nil || !strings.Contains(err.Error(), "dependency injection failed") {
+		t.Fatalf("expected injection error, got %v", err)
+	}
+	if state := container.State(); state != StateFailed {
+		t.Fatalf("expected state failed, got %s", state)
+	}
+	if len(logger.error) != 1 || !strings.HasPrefix(logger.error[0], "ginject: dry run failed:") {
+		t.Fatalf("expected logged failure, got %v", logger.error)
+	}
+}
+
+func TestContainerRunIgnoresDryRunEnvironment(t *testing.T) {
+	t.Setenv(ModeEnv, ModeDryRun)
+	recorder := &panicRecorder{}
+	container := NewContainer(WithLogger(&capturingLogger{}))
+	container.Object(&recordingLifecycleComponent{name: "worker", recorder: recorder})
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	defer container.Stop(context.Background())
+	if state := container.State(); state != StateRunning {
+		t.Fatalf("expected Container.Run to start the container, got state %s", state)
+	}
+}
+
+func TestRunPerformsDryRunFromEnvironment(t *testing.T) {
+	t.Setenv(ModeEnv, ModeDryRun)
+	recorder := &panicRecorder{}
+	container := NewContainer(WithLogger(&capturingLogger{}))
+	container.Object(&recordingLifecycleComponent{name: "worker", recorder: recorder})
+
+	if err := Run(context.Background(), WithContainer(container)); err != nil {
+		t.Fatalf("expected dry run to succeed, got %v", err)
+	}
+	if len(recorder.events) != 0 || container.State() != StateStopped {
+		t.Fatalf("expected no lifecycle calls, got %v in state %s", recorder.events, container.State())
+	}
+}
+
+func TestRunWithDryRunReturnsWithoutWaitingForShutdown(t *testing.T) {
+	recorder := &panicRecorder{}
+	container := NewContainer(WithLogger(&capturingLogger{}))
+	container.Object(&recordingLifecycleComponent{name: "worker", recorder: recorder})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := Run(ctx, WithContainer(container), WithDryRun(true)); err != nil {
+		t.Fatalf("expected dry run to succeed, got %v", err)
+	}
+	if ctx.Err() != nil || len(recorder.events) != 0 {
+		t.Fatalf("expected Run to return before shutdown without lifecycle calls, got %v", recorder.events)
+	}
+
+	failing := NewContainer(WithLogger(&capturingLogger{}))
+	failing.Object(&containerRunApp{})
+	err := Run(ctx, WithContainer(failing), WithDryRun(true))
+	if err == nil || !strings.HasPrefix(err.Error(), "dry run failed: dependency injection failed") {
+		t.Fatalf("expected dry run failure, got %v", err)
+	}
+}
+
+func TestContainerRunPerformsDryRunWithDryRunMode(t *testing.T) {
+	logger := &capturingLogger{}
+	recorder := &panicRecorder{}
+	container := NewContainer(WithDryRunMode(), WithLogger(logger))
+	app := &containerRunApp{}
+	container.Object(app).Name("app")
+	container.Object(&recordingLifecycleComponent{name: "worker", recorder: recorder})
+	container.Object(&containerRunConsoleLogger{}).Name("console").Export((*containerRunLogger)(nil))
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected dry run to succeed, got %v", err)
+	}
+	if app.Logger == nil || app.Started || len(recorder.events) != 0 {
+		t.Fatalf("expected wiring without lifecycle calls, got %v", recorder.events)
+	}
+	if state := container.State(); state != StateStopped {
+		t.Fatalf("expected state stopped, got %s", state)
+	}
+	if len(logger.info) == 0 || !strings.HasPrefix(logger.info[0], "ginject: dry run: 3 components wired") {
+		t.Fatalf("expected dry run summary, got %v", logger.info)
+	}
+
+	failing := NewContainer(WithDryRunMode(), WithLogger(&capturingLogger{}))
+	failing.Object(&containerRunApp{})
+	if err := failing.Run(context.Background()); err == nil || !strings.Contains(err.Error(), "dependency injection failed") {
+		t.Fatalf("expected injection error, got %v", err)
+	}
+}
+
+func TestRunWithDryRunModeReturnsWithoutWaitingForShutdown(t *testing.T) {
+	recorder := &panicRecorder{}
+	container := NewContainer(WithDryRunMode(), WithLogger(&capturingLogger{}))
+	container.Object(&recordingLifecycleComponent{name: "worker", recorder: recorder})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := Run(ctx, WithContainer(container)); err != nil {
+		t.Fatalf("expected dry run to succeed, got %v", err)
+	}
+	if ctx.Err() != nil || len(recorder.events) != 0 {
+		t.Fatalf("expected Run to return before shutdown without lifecycle calls, got %v", recorder.events)
+	}
+}
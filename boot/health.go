@@ -0,0 +1,149 @@
+package boot
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// HealthStatus is the outcome of a health or readiness check
+type HealthStatus string
+
+const (
+	HealthUp   HealthStatus = "up"
+	HealthDown HealthStatus = "down"
+)
+
+// DefaultHealthTimeout is how long Health waits for a single check
+const DefaultHealthTimeout = 5 * time.Second
+
+// CheckResult is the outcome of a single check of a component
+type CheckResult struct {
+	Status   HealthStatus  `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// ComponentHealth holds the check results of a component. A result is nil
+// when the component does not implement the corresponding interface.
+type ComponentHealth struct {
+	Name      string       `json:"name"`
+	Liveness  *CheckResult `json:"liveness,omitempty"`
+	Readiness *CheckResult `json:"readiness,omitempty"`
+}
+
+// HealthReport aggregates the health of a container and its components
+type HealthReport struct {
+	// State is the lifecycle state of the container when the report was taken
+	State State `json:"state"`
+	// Status is down when any liveness check fails or startup failed
+	Status HealthStatus `json:"status"`
+	// Ready is true only while the container is running and every readiness check passes
+	Ready      bool              `json:"ready"`
+	Components []ComponentHealth `json:"components"`
+}
+
+// Health runs the checks of every HealthChecker and ReadinessChecker component
+// concurrently and aggregates the results. Each check is bounded by the
+// container health timeout. Checks only run while the container is running;
+// in any other state the report reflects the lifecycle state alone, so
+// readiness turns false as soon as Stop is called.
+func (c *Container) Health(ctx context.Context) HealthReport {
+	state := c.State()
+	report := HealthReport{
+		State:      state,
+		Status:     HealthUp,
+		Ready:      state == StateRunning,
+		Components: []ComponentHealth{},
+	}
+	if state == StateFailed || state == StateStopped {
+		report.Status = HealthDown
+	}
+	if state != StateRunning {
+		return report
+	}
+
+	c.mu.RLock()
+	components := make([]*ComponentInfo, len(c.components))
+	copy(components, c.components)
+	c.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	results := make([]ComponentHealth, len(components))
+	for i, info := range components {
+		results[i].Name = info.Name
+		if checker, ok := info.Instance.(HealthChecker); ok {
+			wg.Add(1)
+			go func(result **CheckResult) {
+				defer wg.Done()
+				*result = c.runCheck(ctx, checker.CheckHealth)
+			}(&results[i].Liveness)
+		}
+		if checker, ok := info.Instance.(ReadinessChecker); ok {
+			wg.Add(1)
+			go func(result **CheckResult) {
+				defer wg.Done()
+				*result = c.runCheck(ctx, checker.CheckReadiness)
+			}(&results[i].Readiness)
+		}
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		if result.Liveness == nil && result.Readiness == nil {
+			continue
+		}
+		if result.Liveness != nil && result.Liveness.Status == HealthDown {
+			report.Status = HealthDown
+		}
+		if result.Readiness != nil && result.Readiness.Status == HealthDown {
+			report.Ready = false
+		}
+		report.Components = append(report.Components, result)
+	}
+
+	// Shutdown may have been requested while the checks were running
+	if c.State() != StateRunning {
+		report.State = c.State()
+		report.Ready = false
+	}
+	return report
+}
+
+// runCheck runs a single check bounded by the health timeout. A check that
+// does not return in time or panics is reported as down without waiting for it.
+func (c *Container) runCheck(ctx context.Context, check func(ctx context.Context) error) *CheckResult {
+	if c.healthTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.healthTimeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				c.log().Errorf("ginject: health check panicked: %v\n%s", r, debug.Stack())
+				done <- fmt.Errorf("check panicked: %v", r)
+			}
+		}()
+		done <- check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("check did not complete: %w", ctx.Err())
+	}
+
+	result := &CheckResult{Status: HealthUp, Duration: time.Since(start)}
+	if err != nil {
+		result.Status = HealthDown
+		result.Error = err.Error()
+	}
+	return result
+}
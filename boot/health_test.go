@@ -0,0 +1,150 @@
+package boot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type healthyComponent struct{}
+
+func (c *healthyComponent) CheckHealth(context.Context) error {
+	return nil
+}
+
+func (c *healthyComponent) CheckReadiness(context.Context) error {
+	return nil
+}
+
+type unreadyComponent struct{}
+
+func (c *unreadyComponent) CheckReadiness(context.Context) error {
+	return errors.New("warming up")
+}
+
+type hangingHealthComponent struct {
+	release chan struct{}
+}
+
+func (c *hangingHealthComponent) CheckHealth(ctx context.Context) error {
+	<-c.release
+	return nil
+}
+
+func TestContainerHealthAggregatesChecks(t *testing.T) {
+	container := NewContainer()
+	container.Object(&healthyComponent{}).Name("db")
+	container.Object(&unreadyComponent{}).Name("cache")
+	container.Object(&containerRunConsoleLogger{}).Name("logger")
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	defer container.Stop(context.Background())
+
+	report := container.Health(context.Background())
+	if report.State != StateRunning || report.Status != HealthUp {
+		t.Fatalf("expected running and up report, got %s %s", report.State, report.Status)
+	}
+	if report.Ready {
+		t.Fatal("expected failing readiness check to make the container not ready")
+	}
+	if len(report.Components) != 2 {
+		t.Fatalf("expected only checked components in report, got %+v", report.Components)
+	}
+
+	db, cache := report.Components[0], report.Components[1]
+	if db.Name != "db" || db.Liveness.Status != HealthUp || db.Readiness.Status != HealthUp {
+		t.Fatalf("expected db to be up, got %+v", db)
+	}
+	if cache.Name != "cache" || cache.Liveness != nil || cache.Readiness.Error != "warming up" {
+		t.Fatalf("expected cache readiness failure, got %+v", cache)
+	}
+}
+
+func TestContainerHealthTimesOutSlowChecks(t *testing.T) {
+	container := NewContainer(WithHealthTimeout(10 * time.Millisecond))
+	component := &hangingHealthComponent{release: make(chan struct{})}
+	defer close(component.release)
+	container.Object(component).Name("slow")
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	defer container.Stop(context.Background())
+
+	report := container.Health(context.Background())
+	if report.Status != HealthDown {
+		t.Fatalf("expected timed out check to report down, got %s", report.Status)
+	}
+	if !strings.Contains(report.Components[0].Liveness.Error, "deadline exceeded") {
+		t.Fatalf("expected deadline error, got %+v", report.Components[0].Liveness)
+	}
+}
+
+type panickingHealthComponent struct{}
+
+func (c *panickingHealthComponent) CheckHealth(context.Context) error {
+	panic("probe exploded")
+}
+
+func TestContainerHealthReportsPanickingCheckAsDown(t *testing.T) {
+	logger := &capturingLogger{}
+	container := NewContainer(WithLogger(logger))
+	container.Object(&panickingHealthComponent{}).Name("probe")
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	defer container.Stop(context.Background())
+
+	report := container.Health(context.Background())
+	if report.Status != HealthDown {
+		t.Fatalf("expected panicking check to report down, got %s", report.Status)
+	}
+	if got := report.Components[0].Liveness.Error; got != "check panicked: probe exploded" {
+		t.Fatalf("expected panic error, got %q", got)
+	}
+	if len(logger.error) != 1 || !strings.HasPrefix(logger.error[0], "ginject: health check panicked: probe exploded") {
+		t.Fatalf("expected logged panic, got %v", logger.error)
+	}
+}
+
+type healthDuringStopComponent struct {
+	container *Container
+	report    HealthReport
+}
+
+func (c *healthDuringStopComponent) Stop(ctx context.Context) error {
+	c.report = c.container.Health(ctx)
+	return nil
+}
+
+func TestContainerHealthReflectsLifecycleState(t *testing.T) {
+	container := NewContainer()
+	if report := container.Health(context.Background()); report.State != StateCreated || report.Ready {
+		t.Fatalf("expected created container not to be ready, got %+v", report)
+	}
+
+	component := &healthDuringStopComponent{container: container}
+	container.Object(component)
+	container.Object(&healthyComponent{})
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	if report := container.Health(context.Background()); !report.Ready {
+		t.Fatalf("expected running container to be ready, got %+v", report)
+	}
+
+	if err := container.Stop(context.Background()); err != nil {
+		t.Fatalf("expected container to stop, got %v", err)
+	}
+	if component.report.State != StateStopping || component.report.Ready {
+		t.Fatalf("expected readiness to be false while stopping, got %+v", component.report)
+	}
+	if report := container.Health(context.Background()); report.State != StateStopped || report.Status != HealthDown {
+		t.Fatalf("expected stopped container to be down, got %+v", report)
+	}
+}
@@ -0,0 +1,50 @@
+package boot
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// getImplicitUnsafe resolves an interface without an explicit exporter to the
+// registered component implementing it, without locking (assumes caller holds
+// lock). The consumer never resolves to itself, so a wrapper may implement the
+// interface it consumes.
+func (c *Container) getImplicitUnsafe(interfaceType reflect.Type, consumer *ComponentInfo) (interface{}, *ComponentInfo, error) {
+	var implementations []*ComponentInfo
+	for _, info := range c.components {
+		if info != consumer && info.InstanceType.Implements(interfaceType) {
+			implementations = append(implementations, info)
+		}
+	}
+	if len(implementations) == 0 {
+		return nil, nil, fmt.Errorf("no component of type '%s' found", interfaceType)
+	}
+
+	candidates := preferNonFallback(implementations)
+	var selected *ComponentInfo
+	if len(candidates) == 1 {
+		selected = candidates[0]
+	} else {
+		var primaries []string
+		for _, info := range candidates {
+			if info.IsPrimary {
+				selected = info
+				primaries = append(primaries, info.Name)
+			}
+		}
+		if len(primaries) > 1 {
+			return nil, nil, fmt.Errorf("multiple primary implementations of '%s': %v", interfaceType, primaries)
+		}
+		if selected == nil {
+			names := make([]string, len(candidates))
+			for i, info := range candidates {
+				names[i] = info.Name
+			}
+			return nil, nil, fmt.Errorf("ambiguous implementations of '%s': %v (mark one as Primary() or Export the interface)",
+				interfaceType, names)
+		}
+	}
+
+	c.log().Debugf("ginject: implicitly resolved %s to '%s'", interfaceType, selected.Name)
+	return selected.exposed(interfaceType), selected, nil
+}
@@ -0,0 +1,146 @@
+package boot
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type implicitGreeter interface {
+	Greet() string
+}
+
+type implicitEnglishGreeter struct{}
+
+func (g *implicitEnglishGreeter) Greet() string {
+	return "hello"
+}
+
+type implicitFrenchGreeter struct{}
+
+func (g *implicitFrenchGreeter) Greet() string {
+	return "bonjour"
+}
+
+type implicitConsumer struct {
+	Greeter implicitGreeter `autowire:""`
+}
+
+func TestContainerRunResolvesImplicitInterfacesWhenEnabled(t *testing.T) {
+	logger := &capturingLogger{}
+	container := NewContainer(WithImplicitInterfaces(), WithLogger(logger))
+	consumer := &implicitConsumer{}
+	container.Object(&implicitEnglishGreeter{}).Name("english")
+	container.Object(consumer)
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected implicit resolution to succeed, got %v", err)
+	}
+	if consumer.Greeter == nil || consumer.Greeter.Greet() != "hello" {
+		t.Fatal("expected the unique implementation to be injected")
+	}
+	expected := "ginject: implicitly resolved boot.implicitGreeter to 'english'"
+	found := false
+	for _, message := range logger.debug {
+		found = found || message == expected
+	}
+	if !found {
+		t.Fatalf("expected debug log %q, got %v", expected, logger.debug)
+	}
+
+	greeter, err := container.GetByType(reflect.TypeOf((*implicitGreeter)(nil)).Elem())
+	if err != nil || greeter.(implicitGreeter).Greet() != "hello" {
+		t.Fatalf("expected GetByType to resolve implicitly, got %v, %v", greeter, err)
+	}
+}
+
+func TestContainerRunRequiresExportWithoutImplicitInterfaces(t *testing.T) {
+	container := NewContainer()
+	container.Object(&implicitEnglishGreeter{})
+	container.Object(&implicitConsumer{})
+
+	err := container.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "no component of type 'boot.implicitGreeter' found") {
+		t.Fatalf("expected unexported interface to fail by default, got %v", err)
+	}
+}
+
+func TestContainerRunAppliesPrimaryRulesToImplicitInterfaces(t *testing.T) {
+	tests := []struct {
+		name     string
+		register func(c *Container)
+		greeting string
+		expected string
+	}{
+		{
+			name: "ambiguous",
+			register: func(c *Container) {
+				c.Object(&implicitEnglishGreeter{}).Name("english")
+				c.Object(&implicitFrenchGreeter{}).Name("french")
+			},
+			expected: "ambiguous implementations of 'boot.implicitGreeter': [english french]",
+		},
+		{
+			name: "primary",
+			register: func(c *Container) {
+				c.Object(&implicitEnglishGreeter{}).Name("english")
+				c.Object(&implicitFrenchGreeter{}).Name("french").Primary()
+			},
+			greeting: "bonjour",
+		},
+		{
+			name: "fallback",
+			register: func(c *Container) {
+				c.Object(&implicitEnglishGreeter{}).Name("english").Fallback()
+				c.Object(&implicitFrenchGreeter{}).Name("french")
+			},
+			greeting: "bonjour",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			container := NewContainer(WithImplicitInterfaces())
+			consumer := &implicitConsumer{}
+			tt.register(container)
+			container.Object(consumer)
+
+			err := container.Run(context.Background())
+			if tt.expected != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expected) {
+					t.Fatalf("expected error containing %q, got %v", tt.expected, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected implicit resolution to succeed, got %v", err)
+			}
+			if got := consumer.Greeter.Greet(); got != tt.greeting {
+				t.Fatalf("expected %q, got %q", tt.greeting, got)
+			}
+		})
+	}
+}
+
+type implicitLoudGreeter struct {
+	Greeter implicitGreeter `autowire:""`
+}
+
+func (g *implicitLoudGreeter) Greet() string {
+	return strings.ToUpper(g.Greeter.Greet())
+}
+
+func TestContainerRunSkipsConsumerWhenResolvingImplicitInterfaces(t *testing.T) {
+	container := NewContainer(WithImplicitInterfaces())
+	loud := &implicitLoudGreeter{}
+	container.Object(&implicitEnglishGreeter{}).Name("english")
+	container.Object(loud).Name("loud")
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected the wrapper to resolve to the other implementation, got %v", err)
+	}
+	if loud.Greet() != "HELLO" {
+		t.Fatalf("expected the wrapper to wrap english, got %q", loud.Greet())
+	}
+}
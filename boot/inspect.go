@@ -0,0 +1,149 @@
+package boot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ModeEnv is the environment variable selecting the mode of Run and RunApplication
+const ModeEnv = "GINJECT_MODE"
+
+// ModeInspect makes Run wire the container, write an InspectReport as JSON,
+// and exit the process without calling Init or Start. The exit code is 0 when
+// the wiring is valid and 1 otherwise.
+const ModeInspect = "inspect"
+
+// InspectOutputEnv names the file an inspection report is written to instead
+// of standard output
+const InspectOutputEnv = "GINJECT_INSPECT_OUTPUT"
+
+// InspectReport describes the wiring of a container
+type InspectReport struct {
+	Valid bool `json:"valid"`
+	// Error is the registration, validation, or injection error, if any
+	Error      string            `json:"error,omitempty"`
+	Components []ComponentReport `json:"components"`
+	// StartOrder names the components in the order Init and Start are called;
+	// empty when the wiring is invalid
+	StartOrder []string `json:"startOrder,omitempty"`
+}
+
+// ComponentReport describes a registered component and its injected fields
+type ComponentReport struct {
+	Name          string             `json:"name"`
+	Type          string             `json:"type"`
+	ExportedTypes []string           `json:"exportedTypes"`
+	Priority      int                `json:"priority"`
+	Primary       bool               `json:"primary"`
+	Fallback      bool               `json:"fallback"`
+	Labels        map[string]string  `json:"labels,omitempty"`
+	DependsOn     []string           `json:"dependsOn,omitempty"`
+	Dependencies  []DependencyReport `json:"dependencies,omitempty"`
+}
+
+// DependencyReport describes an autowired field and the components injected into it
+type DependencyReport struct {
+	Field      string   `json:"field"`
+	Type       string   `json:"type"`
+	Qualifier  string   `json:"qualifier"`
+	Components []string `json:"components"`
+}
+
+// Inspect registers pending builders, validates the registrations, and
+// injects dependencies like Run, then reports the resulting wiring instead of
+// calling Init and Start. Like Run, it counts as the single run of the
+// default container.
+func (c *Container) Inspect() InspectReport {
+	err := c.wireOnly()
+	if err == ErrAlreadyRun {
+		return InspectReport{Error: err.Error(), Components: []ComponentReport{}}
+	}
+	report := InspectReport{Valid: err == nil, Components: []ComponentReport{}}
+	if err != nil {
+		report.Error = err.Error()
+	}
+	for _, info := range c.Components() {
+		report.Components = append(report.Components, newComponentReport(info))
+	}
+	if err == nil {
+		for _, info := range c.getSortedComponents(false) {
+			report.StartOrder = append(report.StartOrder, info.Name)
+		}
+	}
+	return report
+}
+
+func newComponentReport(info *ComponentInfo) ComponentReport {
+	report := ComponentReport{
+		Name:          info.Name,
+		Type:          info.InstanceType.String(),
+		ExportedTypes: make([]string, len(info.ExportedTypes)),
+		Priority:      info.Priority,
+		Primary:       info.IsPrimary,
+		Fallback:      info.IsFallback,
+		Labels:        info.Labels,
+		DependsOn:     info.DependsOn,
+	}
+	for i, t := range info.ExportedTypes {
+		report.ExportedTypes[i] = t.String()
+	}
+	for _, dependency := range info.Dependencies {
+		report.Dependencies = append(report.Dependencies, DependencyReport{
+			Field:      dependency.Field,
+			Type:       dependency.Type.String(),
+			Qualifier:  dependency.Qualifier,
+			Components: append([]string{}, dependency.Components...),
+		})
+	}
+	return report
+}
+
+// runMode returns the mode selected through ModeEnv
+func runMode() (string, error) {
+	switch mode := os.Getenv(ModeEnv); mode {
+	case "", ModeInspect, ModeDryRun:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown %s %q", ModeEnv, mode)
+	}
+}
+
+// inspectAndExit writes the inspection report of container and exits the
+// process, 0 when the wiring is valid
+func inspectAndExit(container *Container) {
+	report := container.Inspect()
+
+	code := 0
+	if !report.Valid {
+		code = 1
+	}
+	if err := writeInspectReport(report); err != nil {
+		container.log().Errorf("ginject: failed to write inspection report: %v", err)
+		code = 1
+	}
+	exitFunc(code)
+}
+
+func writeInspectReport(report InspectReport) error {
+	path := os.Getenv(InspectOutputEnv)
+	if path == "" {
+		return encodeReport(os.Stdout, report)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := encodeReport(file, report); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func encodeReport(w io.Writer, report InspectReport) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -0,0 +1,110 @@
+package boot
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newInspectedContainer(recorder *panicRecorder) *Container {
+	container := NewContainer()
+	container.Object(&containerRunApp{}).Name("app")
+	container.Object(&recordingLifecycleComponent{name: "worker", recorder: recorder}).Priority(10)
+	container.Object(&containerRunConsoleLogger{}).Name("console").Export((*containerRunLogger)(nil)).Label("tier", "core")
+	return container
+}
+
+func TestContainerInspectReportsWiringWithoutStarting(t *testing.T) {
+	recorder := &panicRecorder{}
+	container := newInspectedContainer(recorder)
+
+	report := container.Inspect()
+	if !report.Valid || report.Error != "" {
+		t.Fatalf("expected valid wiring, got %+v", report)
+	}
+	if len(recorder.events) != 0 {
+		t.Fatalf("expected no lifecycle calls, got %v", recorder.events)
+	}
+	if got := strings.Join(report.StartOrder, ","); got != "worker,app,console" {
+		t.Fatalf("expected start order worker,app,console, got %s", got)
+	}
+
+	app := report.Components[0]
+	if app.Name != "app" || len(app.Dependencies) != 1 {
+		t.Fatalf("expected app with one dependency, got %+v", app)
+	}
+	if dependency := app.Dependencies[0]; dependency.Field != "Logger" || len(dependency.Components) != 1 || dependency.Components[0] != "console" {
+		t.Fatalf("expected Logger to be wired to console, got %+v", dependency)
+	}
+	console := report.Components[2]
+	if console.Labels["tier"] != "core" || len(console.ExportedTypes) != 2 {
+		t.Fatalf("expected console labels and exported types, got %+v", console)
+	}
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected inspected container to run afterwards, got %v", err)
+	}
+	container.Stop(context.Background())
+}
+
+func TestContainerInspectReportsValidationError(t *testing.T) {
+	container := NewContainer()
+	container.Object(&containerRunApp{}).Name("app")
+
+	report := container.Inspect()
+	if report.Valid {
+		t.Fatal("expected invalid wiring")
+	}
+	if !strings.Contains(report.Error, "failed to autowire required field Logger") {
+		t.Fatalf("expected injection error, got %q", report.Error)
+	}
+	if len(report.Components) != 1 || len(report.StartOrder) != 0 {
+		t.Fatalf("expected the registered component without a start order, got %+v", report)
+	}
+}
+
+func TestRunInInspectModeWritesReportAndExits(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "report.json")
+	t.Setenv(ModeEnv, ModeInspect)
+	t.Setenv(InspectOutputEnv, output)
+
+	oldExitFunc := exitFunc
+	t.Cleanup(func() { exitFunc = oldExitFunc })
+	exitCode := -1
+	exitFunc = func(code int) { exitCode = code }
+
+	recorder := &panicRecorder{}
+	if err := Run(context.Background(), WithContainer(newInspectedContainer(recorder))); err != nil {
+		t.Fatalf("expected inspection to succeed, got %v", err)
+	}
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d", exitCode)
+	}
+	if len(recorder.events) != 0 {
+		t.Fatalf("expected no lifecycle calls, got %v", recorder.events)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report InspectReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("expected JSON report, got %v", err)
+	}
+	if !report.Valid || len(report.Components) != 3 {
+		t.Fatalf("expected valid report with 3 components, got %+v", report)
+	}
+}
+
+func TestRunRejectsUnknownMode(t *testing.T) {
+	t.Setenv(ModeEnv, "debug")
+
+	err := Run(context.Background(), WithContainer(NewContainer()))
+	if err == nil || !strings.Contains(err.Error(), `unknown GINJECT_MODE "debug"`) {
+		t.Fatalf("expected unknown mode error, got %v", err)
+	}
+}
@@ -2,6 +2,23 @@ package boot
 
 import "context"
 
+// Phase identifies a lifecycle phase of a component
+type Phase string
+
+const (
+	PhaseInit  Phase = "init"
+	PhaseStart Phase = "start"
+	PhaseStop  Phase = "stop"
+	PhaseRun   Phase = "run"
+
+	// Wiring calls that are recovered like lifecycle calls but not reported
+	// to lifecycle observers
+	PhaseInject     Phase = "inject"
+	PhaseDecorate   Phase = "decorate"
+	PhaseBeforeInit Phase = "before-init"
+	PhaseAfterInit  Phase = "after-init"
+)
+
 // Initializable Lifecycle interfaces for components
 type Initializable interface {
 	Init(ctx context.Context) error
@@ -15,7 +32,34 @@ type Stoppable interface {
 	Stop(ctx context.Context) error
 }
 
+// Runnable is implemented by components that run a long-lived loop. The
+// container calls Run in its own goroutine after startup completes and cancels
+// ctx when the container stops. Returning an error before cancellation is a
+// fatal application error.
+type Runnable interface {
+	Run(ctx context.Context) error
+}
+
+// HealthChecker is implemented by components that report whether they are alive
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// ReadinessChecker is implemented by components that report whether they can
+// serve traffic
+type ReadinessChecker interface {
+	CheckReadiness(ctx context.Context) error
+}
+
 // Named interface for components that provide their own name
 type Named interface {
 	Name() string
 }
+
+// ComponentPostProcessor is implemented by components that observe or wrap
+// other components around their Init call. Each hook returns the instance that
+// should take the place of the component; returning the given instance keeps it.
+type ComponentPostProcessor interface {
+	BeforeInit(info *ComponentInfo, instance interface{}) (interface{}, error)
+	AfterInit(info *ComponentInfo, instance interface{}) (interface{}, error)
+}
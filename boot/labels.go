@@ -0,0 +1,126 @@
+package boot
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// labelSelector matches components carrying a label, and its value when set
+type labelSelector struct {
+	key      string
+	value    string
+	hasValue bool
+}
+
+func (s labelSelector) matches(info *ComponentInfo) bool {
+	value, exists := info.Labels[s.key]
+	return exists && (!s.hasValue || value == s.value)
+}
+
+func (s labelSelector) String() string {
+	if s.hasValue {
+		return "@" + s.key + "=" + s.value
+	}
+	return "@" + s.key
+}
+
+// isLabelQualifier reports whether an autowire qualifier selects components
+// by label, as in "@tier=storage" or "@cache,optional"
+func isLabelQualifier(qualifier string) bool {
+	return strings.HasPrefix(qualifier, "@")
+}
+
+// parseLabelSelectors parses a comma-separated list of label selectors,
+// ignoring the optional markers
+func parseLabelSelectors(qualifier string) ([]labelSelector, error) {
+	var selectors []labelSelector
+	for _, part := range strings.Split(qualifier, ",") {
+		part = strings.TrimSpace(part)
+		if part == "optional" || part == "?" {
+			continue
+		}
+		if !strings.HasPrefix(part, "@") || len(part) == 1 {
+			return nil, fmt.Errorf("invalid label selector %q in qualifier %q", part, qualifier)
+		}
+		key, value, hasValue := strings.Cut(part[1:], "=")
+		selectors = append(selectors, labelSelector{key: key, value: value, hasValue: hasValue})
+	}
+	return selectors, nil
+}
+
+func matchesAll(info *ComponentInfo, selectors []labelSelector) bool {
+	for _, selector := range selectors {
+		if !selector.matches(info) {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveByLabelUnsafe resolves label selectors without locking (assumes
+// caller holds lock). A slice field receives every component exporting its
+// element type that matches the selectors, in registration order; any other
+// field receives the single match, preferring non-fallback components and
+// then the primary one among several.
+func (c *Container) resolveByLabelUnsafe(fieldType reflect.Type, selectors []labelSelector) (interface{}, []*ComponentInfo, error) {
+	componentType := fieldType
+	if fieldType.Kind() == reflect.Slice {
+		componentType = fieldType.Elem()
+	}
+	exported, exists := c.componentsByType[componentType]
+	if !exists {
+		return nil, nil, fmt.Errorf("no component of type '%s' found", componentType)
+	}
+
+	var matched []*ComponentInfo
+	for _, info := range exported.Components {
+		if matchesAll(info, selectors) {
+			matched = append(matched, info)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, nil, fmt.Errorf("no component of type '%s' matches %v", componentType, selectors)
+	}
+
+	if fieldType.Kind() == reflect.Slice {
+		values := reflect.MakeSlice(fieldType, 0, len(matched))
+		for _, info := range matched {
+			values = reflect.Append(values, reflect.ValueOf(exported.instance(info)))
+		}
+		return values.Interface(), matched, nil
+	}
+
+	candidates := preferNonFallback(matched)
+	if len(candidates) == 1 {
+		return exported.instance(candidates[0]), candidates, nil
+	}
+	for _, info := range candidates {
+		if info.IsPrimary {
+			return exported.instance(info), []*ComponentInfo{info}, nil
+		}
+	}
+	names := make([]string, len(candidates))
+	for i, info := range candidates {
+		names[i] = info.Name
+	}
+	return nil, nil, fmt.Errorf("ambiguous components for type '%s' matching %v: %v (mark one as Primary())",
+		componentType, selectors, names)
+}
+
+// FindByLabel returns the components labelled key=value in registration
+// order. An empty value matches every component carrying the key, including
+// tags.
+func (c *Container) FindByLabel(key, value string) []*ComponentInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	selector := labelSelector{key: key, value: value, hasValue: value != ""}
+	var found []*ComponentInfo
+	for _, info := range c.components {
+		if selector.matches(info) {
+			found = append(found, info)
+		}
+	}
+	return found
+}
@@ -0,0 +1,144 @@
+package boot
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type labelStore interface {
+	Region() string
+}
+
+type labelMemoryStore struct {
+	region string
+}
+
+func (s *labelMemoryStore) Region() string {
+	return s.region
+}
+
+type labelConsumer struct {
+	EU      labelStore   `autowire:"@region=eu"`
+	Storage []labelStore `autowire:"@tier=storage"`
+	Cached  labelStore   `autowire:"@tier=storage,@cache"`
+	Primary labelStore   `autowire:"@region"`
+	Missing []labelStore `autowire:"@tier=archive,optional"`
+}
+
+func newLabelContainer() *Container {
+	container := NewContainer()
+	container.Object(&labelMemoryStore{region: "us"}).Name("us").Export((*labelStore)(nil)).
+		Label("tier", "storage").Label("region", "us").Primary()
+	container.Object(&labelMemoryStore{region: "eu"}).Name("eu").Export((*labelStore)(nil)).
+		Label("tier", "storage").Label("region", "eu").Tags("cache")
+	container.Object(&labelMemoryStore{region: "ap"}).Name("ap").Export((*labelStore)(nil)).
+		Label("tier", "cold")
+	return container
+}
+
+func TestContainerRunInjectsByLabel(t *testing.T) {
+	container := newLabelContainer()
+	consumer := &labelConsumer{}
+	container.Object(consumer).Name("consumer")
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected label injection to succeed, got %v", err)
+	}
+	if consumer.EU.Region() != "eu" || consumer.Cached.Region() != "eu" {
+		t.Fatalf("expected eu store for label selectors, got %s and %s", consumer.EU.Region(), consumer.Cached.Region())
+	}
+	if consumer.Primary.Region() != "us" {
+		t.Fatalf("expected primary store among several matches, got %s", consumer.Primary.Region())
+	}
+	regions := make([]string, len(consumer.Storage))
+	for i, store := range consumer.Storage {
+		regions[i] = store.Region()
+	}
+	if strings.Join(regions, ",") != "us,eu" {
+		t.Fatalf("expected every storage store in registration order, got %v", regions)
+	}
+	if consumer.Missing != nil {
+		t.Fatalf("expected optional slice without matches to stay nil, got %v", consumer.Missing)
+	}
+
+	dependencies := container.componentByName["consumer"].Dependencies
+	if !reflect.DeepEqual(dependencies[1].Components, []string{"us", "eu"}) {
+		t.Fatalf("expected slice dependency to record every match, got %v", dependencies[1].Components)
+	}
+}
+
+func TestContainerRunFailsOnUnresolvableLabels(t *testing.T) {
+	tests := []struct {
+		name      string
+		component interface{}
+		expected  string
+	}{
+		{
+			name: "missing",
+			component: &struct {
+				Store labelStore `autowire:"@tier=archive"`
+			}{},
+			expected: "no component of type 'boot.labelStore' matches [@tier=archive]",
+		},
+		{
+			name: "invalid",
+			component: &struct {
+				Store labelStore `autowire:"@tier=storage,eu"`
+			}{},
+			expected: "invalid label selector \"eu\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			container := newLabelContainer()
+			container.Object(tt.component).Name("consumer")
+
+			err := container.Run(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Fatalf("expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestContainerRunRejectsAmbiguousLabelMatch(t *testing.T) {
+	container := NewContainer()
+	container.Object(&labelMemoryStore{region: "us"}).Name("us").Export((*labelStore)(nil)).Tags("cache").Primary()
+	container.Object(&labelMemoryStore{region: "eu"}).Name("eu").Export((*labelStore)(nil)).Tags("cache", "fast")
+	container.Object(&labelMemoryStore{region: "ap"}).Name("ap").Export((*labelStore)(nil)).Tags("fast")
+	container.Object(&struct {
+		Store labelStore `autowire:"@fast"`
+	}{})
+
+	err := container.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "ambiguous components for type 'boot.labelStore' matching [@fast]: [eu ap]") {
+		t.Fatalf("expected ambiguous label match, got %v", err)
+	}
+}
+
+func TestContainerFindByLabel(t *testing.T) {
+	container := newLabelContainer()
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+
+	names := func(components []*ComponentInfo) string {
+		result := make([]string, len(components))
+		for i, info := range components {
+			result[i] = info.Name
+		}
+		return strings.Join(result, ",")
+	}
+	if got := names(container.FindByLabel("tier", "storage")); got != "us,eu" {
+		t.Fatalf("expected storage components, got %s", got)
+	}
+	if got := names(container.FindByLabel("region", "")); got != "us,eu" {
+		t.Fatalf("expected components carrying region, got %s", got)
+	}
+	if got := names(container.FindByLabel("cache", "")); got != "eu" {
+		t.Fatalf("expected tagged component, got %s", got)
+	}
+}
@@ -0,0 +1,80 @@
+package boot
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// injectMethodName is the conventional injection method registered by
+// ObjectBuilder.Inject without names
+const injectMethodName = "Inject"
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// injectMethodsUnsafe invokes the methods registered with
+// ObjectBuilder.Inject in order, with parameters resolved by type, without
+// locking (assumes caller holds lock). Methods are never invoked without
+// registration, even when named Inject.
+func (c *Container) injectMethodsUnsafe(info *ComponentInfo) error {
+	instance := reflect.ValueOf(info.Instance)
+
+	for _, name := range info.InjectMethods {
+		method := instance.MethodByName(name)
+		if !method.IsValid() {
+			return fmt.Errorf("injection method %s not found on %s", name, info.InstanceType)
+		}
+		if err := c.invokeInjectMethodUnsafe(info, name, method); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// invokeInjectMethodUnsafe resolves the parameters of a single injection
+// method and calls it
+func (c *Container) invokeInjectMethodUnsafe(info *ComponentInfo, name string, method reflect.Value) error {
+	methodType := method.Type()
+	if methodType.IsVariadic() {
+		return fmt.Errorf("injection method %s must not be variadic", name)
+	}
+	if methodType.NumOut() > 1 || (methodType.NumOut() == 1 && methodType.Out(0) != errorType) {
+		return fmt.Errorf("injection method %s must return nothing or an error", name)
+	}
+
+	args := make([]reflect.Value, methodType.NumIn())
+	for i := range args {
+		paramType := methodType.In(i)
+		dependency, source, err := c.getByTypeUnsafe(paramType, info)
+		if err != nil {
+			return fmt.Errorf("failed to resolve parameter %d of injection method %s: %w", i, name, err)
+		}
+		args[i] = reflect.ValueOf(dependency)
+		info.Dependencies = append(info.Dependencies, Dependency{
+			Field:      fmt.Sprintf("%s(%d)", name, i),
+			Type:       paramType,
+			Components: []string{source.Name},
+		})
+	}
+
+	var results []reflect.Value
+	if err := c.recoverPanic(info, PhaseInject, func() error {
+		results = method.Call(args)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(results) == 1 && !results[0].IsNil() {
+		return fmt.Errorf("injection method %s failed: %w", name, results[0].Interface().(error))
+	}
+	return nil
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
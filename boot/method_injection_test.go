@@ -0,0 +1,122 @@
+package boot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type methodInjectionClient struct {
+	mu     sync.Mutex
+	logger containerRunLogger
+	app    *containerRunApp
+	calls  []string
+}
+
+func (c *methodInjectionClient) Inject(logger containerRunLogger, app *containerRunApp) {
+	c.logger = logger
+	c.app = app
+	c.calls = append(c.calls, "Inject")
+}
+
+func (c *methodInjectionClient) SetLogger(logger containerRunLogger) error {
+	if logger == nil {
+		return errors.New("nil logger")
+	}
+	c.calls = append(c.calls, "SetLogger")
+	return nil
+}
+
+func (c *methodInjectionClient) Init(context.Context) error {
+	if c.logger == nil || c.app == nil || c.app.Logger == nil {
+		return errors.New("dependencies not injected before Init")
+	}
+	c.calls = append(c.calls, "Init")
+	return nil
+}
+
+func TestContainerRunInvokesInjectionMethodsBeforeInit(t *testing.T) {
+	container := NewContainer()
+	client := &methodInjectionClient{}
+	container.Object(&containerRunConsoleLogger{}).Export((*containerRunLogger)(nil)).Name("console")
+	container.Object(&containerRunApp{}).Name("app")
+	container.Object(client).Name("client").Inject().Inject("SetLogger")
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected method injection to succeed, got %v", err)
+	}
+	if got := strings.Join(client.calls, ","); got != "Inject,SetLogger,Init" {
+		t.Fatalf("expected Inject, SetLogger then Init, got %s", got)
+	}
+
+	info := container.componentByName["client"]
+	fields := make([]string, len(info.Dependencies))
+	for i, dependency := range info.Dependencies {
+		fields[i] = dependency.Field + "=" + strings.Join(dependency.Components, "")
+	}
+	if got := strings.Join(fields, ","); got != "Inject(0)=console,Inject(1)=app,SetLogger(0)=console" {
+		t.Fatalf("expected method parameters to be recorded as dependencies, got %s", got)
+	}
+}
+
+type methodInjectionFailing struct{}
+
+func (f *methodInjectionFailing) Connect(containerRunLogger) error {
+	return errors.New("refused")
+}
+
+func (f *methodInjectionFailing) Count() int {
+	return 0
+}
+
+func TestContainerRunFailsOnInvalidInjectionMethods(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		register bool
+		expected string
+	}{
+		{name: "missing", method: "SetMissing", register: true, expected: "injection method SetMissing not found"},
+		{name: "error", method: "Connect", register: true, expected: "injection method Connect failed: refused"},
+		{name: "unresolved", method: "Connect", expected: "failed to resolve parameter 0 of injection method Connect"},
+		{name: "result", method: "Count", register: true, expected: "must return nothing or an error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			container := NewContainer()
+			if tt.register {
+				container.Object(&containerRunConsoleLogger{}).Export((*containerRunLogger)(nil))
+			}
+			container.Object(&methodInjectionFailing{}).Name("failing").Inject(tt.method)
+
+			err := container.Run(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Fatalf("expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+type unregisteredInjectComponent struct {
+	called bool
+}
+
+func (c *unregisteredInjectComponent) Inject(ctx context.Context, name string) {
+	c.called = true
+}
+
+func TestContainerRunSkipsUnregisteredInjectMethod(t *testing.T) {
+	container := NewContainer()
+	component := &unregisteredInjectComponent{}
+	container.Object(component).Name("component")
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected an unregistered Inject method to be ignored, got %v", err)
+	}
+	if component.called {
+		t.Fatal("expected Inject not to be called without registration")
+	}
+}
@@ -0,0 +1,110 @@
+package boot
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// autowireNew is the tag value allocating a nested struct pointer instead of
+// resolving it from the container
+const autowireNew = "new"
+
+// injectionState tracks the injection of a single component
+type injectionState struct {
+	// consumer is the component being injected
+	consumer     *ComponentInfo
+	dependencies []Dependency
+	// visited holds the structs already injected, so pointer cycles terminate.
+	// It stays small, so a slice is cheaper than a map.
+	visited []visitedStruct
+	// allocating holds the struct types being allocated on the current path
+	allocating map[reflect.Type]bool
+}
+
+type visitedStruct struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// newInjectionState returns an empty state sized for the given number of
+// dependencies
+func newInjectionState(consumer *ComponentInfo, dependencies int) *injectionState {
+	return &injectionState{
+		consumer:     consumer,
+		dependencies: make([]Dependency, 0, dependencies),
+		visited:      make([]visitedStruct, 0, 4),
+	}
+}
+
+// visit marks the struct behind ptr as injected, reporting false when it
+// already was. The type is part of the key because an embedded struct shares
+// its address with the outer struct.
+func (s *injectionState) visit(ptr reflect.Value) bool {
+	key := visitedStruct{ptr: ptr.Pointer(), typ: ptr.Type()}
+	for _, visited := range s.visited {
+		if visited == key {
+			return false
+		}
+	}
+	s.visited = append(s.visited, key)
+	return true
+}
+
+// injectNewField handles a field tagged autowire:"new": a nil struct pointer
+// is allocated, then the struct is injected like a nested struct
+func (c *Container) injectNewField(field reflect.Value, name string, state *injectionState) error {
+	switch {
+	case field.Kind() == reflect.Struct:
+		return c.injectFieldRecursively(field, name+".", state)
+	case field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct:
+		if !field.IsNil() {
+			return c.injectFieldRecursively(field, name+".", state)
+		}
+		if state.allocating[field.Type().Elem()] {
+			return fmt.Errorf("cannot allocate field %s: %s is already being allocated on this path",
+				name, field.Type().Elem())
+		}
+		return c.allocateAndInject(field, name+".", state)
+	default:
+		return fmt.Errorf("cannot allocate field %s: autowire:\"new\" requires a struct or struct pointer, got %s",
+			name, field.Type())
+	}
+}
+
+// allocateAndInject sets a nil struct pointer field to a new zero value and
+// injects it
+func (c *Container) allocateAndInject(field reflect.Value, path string, state *injectionState) error {
+	elem := field.Type().Elem()
+	if state.allocating == nil {
+		state.allocating = make(map[reflect.Type]bool)
+	}
+	state.allocating[elem] = true
+	defer delete(state.allocating, elem)
+
+	field.Set(reflect.New(elem))
+	return c.injectComponentUnsafe(field.Interface(), path, state)
+}
+
+// declaresAutowireFields reports whether a struct type, or a struct nested in
+// it, has a field tagged autowire
+func declaresAutowireFields(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if t.Kind() != reflect.Struct || seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if _, exists := field.Tag.Lookup("autowire"); exists {
+			return true
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if declaresAutowireFields(fieldType, seen) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package boot
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type nestedLoggerHolder struct {
+	Logger containerRunLogger `autowire:""`
+}
+
+type nestedPlainConfig struct {
+	Path string
+}
+
+type nestedTaggedService struct {
+	Holder *nestedLoggerHolder `autowire:"new"`
+	Value  nestedLoggerHolder  `autowire:"new"`
+}
+
+type nestedEmbeddedService struct {
+	*nestedLoggerHolder
+	Config *nestedPlainConfig
+}
+
+type nestedNode struct {
+	Logger containerRunLogger `autowire:""`
+	Next   *nestedNode
+}
+
+type nestedCyclicAllocation struct {
+	Logger containerRunLogger      `autowire:""`
+	Next   *nestedCyclicAllocation `autowire:"new"`
+}
+
+type nestedInvalidAllocation struct {
+	Logger *containerRunLogger `autowire:"new"`
+}
+
+func newNestedContainer(opts ...ContainerOption) *Container {
+	container := NewContainer(opts...)
+	container.Object(&containerRunConsoleLogger{}).Export((*containerRunLogger)(nil))
+	return container
+}
+
+func TestContainerRunAllocatesFieldsTaggedNew(t *testing.T) {
+	container := newNestedContainer()
+	service := &nestedTaggedService{}
+	container.Object(service)
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected tagged fields to be allocated, got %v", err)
+	}
+	if service.Holder == nil || service.Holder.Logger == nil {
+		t.Fatal("expected nil pointer tagged new to be allocated and injected")
+	}
+	if service.Value.Logger == nil {
+		t.Fatal("expected struct tagged new to be injected")
+	}
+}
+
+func TestContainerRunAllocatesNestedPointersWhenEnabled(t *testing.T) {
+	withoutOption := newNestedContainer()
+	skipped := &nestedEmbeddedService{}
+	withoutOption.Object(skipped)
+	if err := withoutOption.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	if skipped.nestedLoggerHolder != nil {
+		t.Fatal("expected nil embedded pointer to be skipped by default")
+	}
+
+	container := newNestedContainer(WithNestedAllocation(), WithUnexportedFieldInjection())
+	service := &nestedEmbeddedService{}
+	container.Object(service)
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected nested allocation to succeed, got %v", err)
+	}
+	if service.nestedLoggerHolder == nil || service.Logger == nil {
+		t.Fatal("expected embedded pointer to be allocated and injected")
+	}
+	if service.Config != nil {
+		t.Fatal("expected pointer to a struct without autowire fields to stay nil")
+	}
+}
+
+func TestContainerRunTerminatesOnSelfReferentialPointers(t *testing.T) {
+	container := newNestedContainer(WithNestedAllocation())
+	node := &nestedNode{}
+	node.Next = node
+	container.Object(node)
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected pointer cycle to be injected once, got %v", err)
+	}
+	if node.Logger == nil {
+		t.Fatal("expected node to be injected")
+	}
+
+	chained := &nestedNode{}
+	other := NewContainer(WithNestedAllocation())
+	other.Object(&containerRunConsoleLogger{}).Export((*containerRunLogger)(nil))
+	other.Object(chained)
+	if err := other.Run(context.Background()); err != nil {
+		t.Fatalf("expected recursive type to be allocated once, got %v", err)
+	}
+	if chained.Next == nil || chained.Next.Next != nil {
+		t.Fatal("expected a recursive struct type to be allocated a single level deep")
+	}
+}
+
+func TestContainerRunRejectsInvalidNewFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		component interface{}
+		expected  string
+	}{
+		{name: "cycle", component: &nestedCyclicAllocation{}, expected: "is already being allocated"},
+		{name: "kind", component: &nestedInvalidAllocation{}, expected: "requires a struct or struct pointer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			container := newNestedContainer()
+			container.Object(tt.component)
+
+			err := container.Run(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Fatalf("expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
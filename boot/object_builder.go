@@ -15,6 +15,13 @@ type ObjectBuilder struct {
 	nameSet       bool
 	prioritySet   bool
 	isPrimary     bool
+	isFallback    bool
+	replacesName  string
+	replacesType  reflect.Type
+	restartPolicy RestartPolicy
+	injectMethods []string
+	labels        map[string]string
+	dependsOn     []string
 	err           error
 }
 
@@ -75,16 +82,113 @@ func (b *ObjectBuilder) Export(typePtr interface{}) *ObjectBuilder {
 
 // Primary marks this component as the primary implementation for its exported types
 func (b *ObjectBuilder) Primary() *ObjectBuilder {
+	if b.isFallback {
+		b.err = fmt.Errorf("component cannot be both Primary and Fallback")
+	}
 	b.isPrimary = true
 	return b
 }
 
+// Fallback marks this component as a default implementation for its exported
+// types. It is injected only when no other component exports the same type,
+// so a user-provided implementation replaces it without calling Primary.
+func (b *ObjectBuilder) Fallback() *ObjectBuilder {
+	if b.isPrimary {
+		b.err = fmt.Errorf("component cannot be both Primary and Fallback")
+	}
+	b.isFallback = true
+	return b
+}
+
+// RestartPolicy sets how the container restarts this component's Run loop
+// when it implements Runnable
+func (b *ObjectBuilder) RestartPolicy(policy RestartPolicy) *ObjectBuilder {
+	b.restartPolicy = policy
+	return b
+}
+
+// DependsOn orders this component after the named components: they are
+// initialized and started before it and stopped after it, whatever their
+// priority. Run fails if a name is unknown or the edges form a cycle.
+func (b *ObjectBuilder) DependsOn(names ...string) *ObjectBuilder {
+	b.dependsOn = append(b.dependsOn, names...)
+	return b
+}
+
+// Label attaches a key/value label to the component, usable in FindByLabel
+// and in autowire qualifiers such as autowire:"@tier=storage"
+func (b *ObjectBuilder) Label(key, value string) *ObjectBuilder {
+	if b.labels == nil {
+		b.labels = make(map[string]string)
+	}
+	b.labels[key] = value
+	return b
+}
+
+// Tags attaches labels without a value, matched by key alone as in
+// autowire:"@storage"
+func (b *ObjectBuilder) Tags(tags ...string) *ObjectBuilder {
+	for _, tag := range tags {
+		b.Label(tag, "")
+	}
+	return b
+}
+
+// Inject registers methods to invoke after field injection and before Init.
+// Their parameters are resolved by type, and they may return an error.
+// Without names it registers the conventional method named Inject.
+func (b *ObjectBuilder) Inject(methods ...string) *ObjectBuilder {
+	if len(methods) == 0 {
+		methods = []string{injectMethodName}
+	}
+	b.injectMethods = append(b.injectMethods, methods...)
+	return b
+}
+
+// Replaces marks this component as an explicit replacement for the component
+// registered under name. The replacement inherits the name, priority, primary
+// and fallback flags, labels, DependsOn edges and exported interfaces of the
+// replaced component unless set explicitly.
+func (b *ObjectBuilder) Replaces(name string) *ObjectBuilder {
+	b.replacesName = name
+	return b
+}
+
+// replacesTypeOf marks this component as a replacement for the component
+// resolved for the given type
+func (b *ObjectBuilder) replacesTypeOf(typePtr interface{}) *ObjectBuilder {
+	t := reflect.TypeOf(typePtr)
+	if t == nil {
+		b.err = fmt.Errorf("cannot override nil type")
+		return b
+	}
+	if t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Interface {
+		t = t.Elem()
+	}
+	b.replacesType = t
+	return b
+}
+
+// isReplacement reports whether this builder overrides another registration
+func (b *ObjectBuilder) isReplacement() bool {
+	return b.replacesName != "" || b.replacesType != nil
+}
+
 // Register completes the component registration
 func (b *ObjectBuilder) register() error {
 	if b.err != nil {
 		return b.err
 	}
 
+	if b.isReplacement() {
+		return b.container.overrideComponent(b)
+	}
+
+	return b.container.registerComponent(b.componentInfo())
+}
+
+// componentInfo builds the ComponentInfo described by the builder
+func (b *ObjectBuilder) componentInfo() *ComponentInfo {
 	// Use type name as default if no name is set
 	if b.name == "" {
 		instanceType := reflect.TypeOf(b.instance)
@@ -100,14 +204,17 @@ func (b *ObjectBuilder) register() error {
 		}
 	}
 
-	info := &ComponentInfo{
+	return &ComponentInfo{
 		Instance:      b.instance,
 		InstanceType:  reflect.TypeOf(b.instance),
 		Name:          b.name,
 		Priority:      b.priority,
 		ExportedTypes: b.exportedTypes,
 		IsPrimary:     b.isPrimary,
+		IsFallback:    b.isFallback,
+		RestartPolicy: b.restartPolicy,
+		InjectMethods: b.injectMethods,
+		Labels:        b.labels,
+		DependsOn:     b.dependsOn,
 	}
-
-	return b.container.registerComponent(info)
 }
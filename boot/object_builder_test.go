@@ -1,6 +1,7 @@
 package boot
 
 import (
+	"context"
 	"reflect"
 	"strings"
 	"testing"
@@ -51,3 +52,66 @@ func TestObjectBuilderRegisterAcceptsAssignableInterfaceExportType(t *testing.T)
 		t.Fatalf("expected resolved component to implement exportValidationLogger, got %T", component)
 	}
 }
+
+type fallbackFileLogger struct{}
+
+func (l *fallbackFileLogger) Log(string) {}
+
+type fallbackNopLogger struct{}
+
+func (l *fallbackNopLogger) Log(string) {}
+
+func resolveFallbackLogger(t *testing.T, register func(c *Container)) (exportValidationLogger, error) {
+	t.Helper()
+	container := NewContainer()
+	register(container)
+	if err := container.Run(context.Background()); err != nil {
+		return nil, err
+	}
+	component, err := container.GetByType(reflect.TypeOf((*exportValidationLogger)(nil)).Elem())
+	if err != nil {
+		t.Fatalf("expected logger to resolve, got %v", err)
+	}
+	return component.(exportValidationLogger), nil
+}
+
+func TestObjectBuilderFallbackLosesToOtherComponents(t *testing.T) {
+	logger, err := resolveFallbackLogger(t, func(c *Container) {
+		c.Object(&fallbackNopLogger{}).Export((*exportValidationLogger)(nil)).Fallback()
+	})
+	if err != nil {
+		t.Fatalf("expected fallback alone to validate, got %v", err)
+	}
+	if _, ok := logger.(*fallbackNopLogger); !ok {
+		t.Fatalf("expected fallback when nothing else exports the type, got %T", logger)
+	}
+
+	logger, err = resolveFallbackLogger(t, func(c *Container) {
+		c.Object(&fallbackNopLogger{}).Export((*exportValidationLogger)(nil)).Fallback()
+		c.Object(&exportValidationConsoleLogger{}).Export((*exportValidationLogger)(nil))
+	})
+	if err != nil {
+		t.Fatalf("expected user component to win without Primary, got %v", err)
+	}
+	if _, ok := logger.(*exportValidationConsoleLogger); !ok {
+		t.Fatalf("expected user component over fallback, got %T", logger)
+	}
+}
+
+func TestObjectBuilderFallbackReportsAmbiguityAmongOtherComponents(t *testing.T) {
+	_, err := resolveFallbackLogger(t, func(c *Container) {
+		c.Object(&fallbackNopLogger{}).Name("nop").Export((*exportValidationLogger)(nil)).Fallback()
+		c.Object(&exportValidationConsoleLogger{}).Name("console").Export((*exportValidationLogger)(nil))
+		c.Object(&fallbackFileLogger{}).Name("file").Export((*exportValidationLogger)(nil))
+	})
+	if err == nil || !strings.Contains(err.Error(), "ambiguous components for type 'boot.exportValidationLogger': [console file]") {
+		t.Fatalf("expected ambiguity among non-fallback components only, got %v", err)
+	}
+
+	_, err = resolveFallbackLogger(t, func(c *Container) {
+		c.Object(&fallbackNopLogger{}).Export((*exportValidationLogger)(nil)).Fallback().Primary()
+	})
+	if err == nil || !strings.Contains(err.Error(), "both Primary and Fallback") {
+		t.Fatalf("expected Primary and Fallback to be rejected together, got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package boot
+
+import "time"
+
+// LifecycleEvent describes a completed lifecycle call of a component
+type LifecycleEvent struct {
+	Component string
+	Phase     Phase
+	Duration  time.Duration
+	// Err is the error returned by the call, a *ComponentPanicError when it
+	// panicked, or nil
+	Err error
+}
+
+// LifecycleObserver is notified after every Init, Start, Stop and Run call
+type LifecycleObserver func(event LifecycleEvent)
+
+// notify passes an event to every registered observer in registration order
+func (c *Container) notify(event LifecycleEvent) {
+	for _, observer := range c.observers {
+		observer(event)
+	}
+}
+
+// logLifecycleEvent logs a lifecycle call at debug level
+func (c *Container) logLifecycleEvent(event LifecycleEvent) {
+	if event.Err != nil {
+		c.log().Debugf("ginject: %s '%s' failed after %s: %v", event.Phase, event.Component, event.Duration, event.Err)
+		return
+	}
+	c.log().Debugf("ginject: %s '%s' took %s", event.Phase, event.Component, event.Duration)
+}
@@ -0,0 +1,83 @@
+package boot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLifecycleObserverReceivesEveryCall(t *testing.T) {
+	var mu sync.Mutex
+	var events []LifecycleEvent
+	logger := &capturingLogger{}
+	container := NewContainer(WithLogger(logger), WithLifecycleObserver(func(event LifecycleEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}))
+	container.Object(&recordingLifecycleComponent{name: "worker", recorder: &panicRecorder{}}).Name("worker")
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected run to succeed, got %v", err)
+	}
+	if err := container.Stop(context.Background()); err != nil {
+		t.Fatalf("expected stop to succeed, got %v", err)
+	}
+
+	var got []string
+	for _, event := range events {
+		if event.Err != nil {
+			t.Fatalf("expected no error, got %v", event.Err)
+		}
+		got = append(got, fmt.Sprintf("%s %s", event.Phase, event.Component))
+	}
+	if strings.Join(got, ", ") != "start worker, stop worker" {
+		t.Fatalf("expected start and stop events, got %v", got)
+	}
+	if len(logger.debug) != 0 {
+		t.Fatalf("expected no lifecycle logging by default, got %v", logger.debug)
+	}
+}
+
+func TestLifecycleObserverReceivesFailuresAndPanics(t *testing.T) {
+	var events []LifecycleEvent
+	container := NewContainer(WithLogger(&capturingLogger{}), WithLifecycleObserver(func(event LifecycleEvent) {
+		events = append(events, event)
+	}))
+	container.Object(&failingStartComponent{}).Name("server")
+
+	if err := container.Run(context.Background()); err == nil {
+		t.Fatal("expected start error")
+	}
+	if len(events) != 1 || events[0].Phase != PhaseStart || events[0].Err == nil || events[0].Err.Error() != "boom" {
+		t.Fatalf("expected failed start event, got %v", events)
+	}
+
+	events = nil
+	container = NewContainer(WithLogger(&capturingLogger{}), WithLifecycleObserver(func(event LifecycleEvent) {
+		events = append(events, event)
+	}))
+	container.Object(&panickingInitComponent{}).Name("broken")
+
+	_ = container.Run(context.Background())
+	var panicErr *ComponentPanicError
+	if len(events) != 1 || events[0].Phase != PhaseInit || !errors.As(events[0].Err, &panicErr) {
+		t.Fatalf("expected panicked init event, got %v", events)
+	}
+}
+
+func TestWithLifecycleLoggingLogsAtDebugLevel(t *testing.T) {
+	logger := &capturingLogger{}
+	container := NewContainer(WithLogger(logger), WithLifecycleLogging())
+	container.Object(&recordingLifecycleComponent{name: "worker", recorder: &panicRecorder{}}).Name("worker")
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected run to succeed, got %v", err)
+	}
+	if len(logger.debug) != 1 || !strings.HasPrefix(logger.debug[0], "ginject: start 'worker' took ") {
+		t.Fatalf("expected start to be logged, got %v", logger.debug)
+	}
+}
@@ -0,0 +1,197 @@
+package boot
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"time"
+)
+
+// ContainerOption configures a Container created with NewContainer
+type ContainerOption func(*Container)
+
+// WithLogger sets the logger used by the container instead of the package-level logger
+func WithLogger(logger Logger) ContainerOption {
+	return func(c *Container) {
+		c.logger = logger
+	}
+}
+
+// DefaultStopTimeout is how long Stop waits for a Runnable component to return
+// after cancelling it
+const DefaultStopTimeout = 30 * time.Second
+
+// WithStopTimeout sets how long Stop waits for each Runnable component to
+// return after cancelling it. A zero or negative timeout waits indefinitely.
+func WithStopTimeout(timeout time.Duration) ContainerOption {
+	return func(c *Container) {
+		c.stopTimeout = timeout
+	}
+}
+
+// WithHealthTimeout sets how long Health waits for each health or readiness
+// check. A zero or negative timeout only applies the deadline of the caller.
+func WithHealthTimeout(timeout time.Duration) ContainerOption {
+	return func(c *Container) {
+		c.healthTimeout = timeout
+	}
+}
+
+// WithParallelLifecycle makes Init and Start run concurrently for components
+// of equal priority that do not depend on each other. Dependencies still
+// complete before their dependents, at most limit components run at once
+// (zero or negative means no limit), and the first failure cancels the
+// context of the running siblings. Init runs sequentially when
+// post-processors are registered.
+func WithParallelLifecycle(limit int) ContainerOption {
+	return func(c *Container) {
+		c.parallel = true
+		c.parallelLimit = limit
+	}
+}
+
+// WithUnexportedFieldInjection lets the container inject autowire-tagged
+// unexported fields and scan unexported nested structs. Without it, an
+// autowire tag on an unexported field fails injection.
+func WithUnexportedFieldInjection() ContainerOption {
+	return func(c *Container) {
+		c.injectUnexported = true
+	}
+}
+
+// WithNestedAllocation makes the container allocate nil pointers to structs
+// declaring autowire fields, such as an embedded *Dependencies, and inject
+// them. Without it, nil pointer fields are skipped unless tagged autowire:"new".
+func WithNestedAllocation() ContainerOption {
+	return func(c *Container) {
+		c.allocateNested = true
+	}
+}
+
+// WithImplicitInterfaces lets the container resolve an interface that no
+// component exports to the unique registered component implementing it,
+// following the Primary and Fallback rules. Each implicit binding is logged
+// at debug level.
+func WithImplicitInterfaces() ContainerOption {
+	return func(c *Container) {
+		c.implicitIfaces = true
+	}
+}
+
+// WithDryRunMode makes Container.Run, Run and RunApplication perform a
+// Container.DryRun instead of calling Init and Start, independent of
+// GINJECT_MODE
+func WithDryRunMode() ContainerOption {
+	return func(c *Container) {
+		c.dryRun = true
+	}
+}
+
+// WithLifecycleObserver registers an observer notified after each Init,
+// Start, Stop and Run call of a component. Observers run synchronously in
+// registration order and may be called concurrently, from parallel lifecycle
+// phases and runner goroutines.
+func WithLifecycleObserver(observer LifecycleObserver) ContainerOption {
+	return func(c *Container) {
+		c.observers = append(c.observers, observer)
+	}
+}
+
+// WithLifecycleLogging logs every Init, Start, Stop and Run call of a
+// component with its duration at debug level
+func WithLifecycleLogging() ContainerOption {
+	return func(c *Container) {
+		c.observers = append(c.observers, c.logLifecycleEvent)
+	}
+}
+
+// Logger returns the logger of the container, which is the package-level
+// logger unless WithLogger was given
+func (c *Container) Logger() Logger {
+	return c.log()
+}
+
+// log returns the container logger, falling back to the package-level logger
+func (c *Container) log() Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+	return defaultLogger
+}
+
+// RunOption configures Run and RunApplication
+type RunOption func(*runConfig)
+
+type runConfig struct {
+	container     *Container
+	signals       []os.Signal
+	reloadHook    func(ctx context.Context) error
+	reloadSignals []os.Signal
+	forceExitCode int
+	dryRun        bool
+}
+
+// DefaultForceExitCode is the exit code used when a termination signal
+// arrives while a graceful shutdown is still in progress
+const DefaultForceExitCode = 2
+
+// WithContainer makes Run and RunApplication run c instead of the default container
+func WithContainer(c *Container) RunOption {
+	return func(cfg *runConfig) {
+		cfg.container = c
+	}
+}
+
+// WithSignals sets the OS signals that trigger graceful shutdown (defaults to
+// SIGINT and SIGTERM). Calling it without signals disables signal handling.
+func WithSignals(signals ...os.Signal) RunOption {
+	return func(cfg *runConfig) {
+		cfg.signals = signals
+	}
+}
+
+// WithReload calls hook when one of the given signals arrives instead of
+// shutting down (defaults to SIGHUP). A failing hook is logged and the
+// application keeps running.
+func WithReload(hook func(ctx context.Context) error, signals ...os.Signal) RunOption {
+	return func(cfg *runConfig) {
+		cfg.reloadHook = hook
+		cfg.reloadSignals = signals
+		if len(signals) == 0 {
+			cfg.reloadSignals = []os.Signal{syscall.SIGHUP}
+		}
+	}
+}
+
+// WithForceExitCode sets the exit code used when a shutdown signal arrives
+// during a graceful shutdown (defaults to DefaultForceExitCode)
+func WithForceExitCode(code int) RunOption {
+	return func(cfg *runConfig) {
+		cfg.forceExitCode = code
+	}
+}
+
+// WithDryRun makes Run perform a Container.DryRun and return without waiting
+// for shutdown when enabled, so it can be bound to a command line flag:
+//
+//	boot.RunApplication(boot.WithDryRun(*dryRun))
+func WithDryRun(enabled bool) RunOption {
+	return func(cfg *runConfig) {
+		cfg.dryRun = enabled
+	}
+}
+
+// newRunConfig applies run options on top of the defaults
+func newRunConfig(opts []RunOption) *runConfig {
+	cfg := &runConfig{
+		signals:       []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+		forceExitCode: DefaultForceExitCode,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.container == nil {
+		cfg.container = Default()
+	}
+	return cfg
+}
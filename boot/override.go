@@ -0,0 +1,129 @@
+package boot
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Override registers instance as an explicit replacement for an existing
+// component. The target is either a component name or a type pointer such as
+// (*UserService)(nil), which selects the component resolved for that type.
+// Overrides are applied after all other registrations, and Run fails if the
+// target does not exist so stale overrides are caught.
+func (c *Container) Override(target interface{}, instance interface{}) *ObjectBuilder {
+	builder := c.Object(instance)
+	if name, ok := target.(string); ok {
+		return builder.Replaces(name)
+	}
+	return builder.replacesTypeOf(target)
+}
+
+// overrideComponent replaces the component targeted by the builder
+func (c *Container) overrideComponent(b *ObjectBuilder) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	target, err := c.findOverrideTargetUnsafe(b)
+	if err != nil {
+		return err
+	}
+
+	if !b.nameSet {
+		b.name = target.Name
+	}
+	if !b.prioritySet {
+		b.priority = target.Priority
+	}
+	b.isPrimary = b.isPrimary || (target.IsPrimary && !b.isFallback)
+	b.isFallback = b.isFallback || (target.IsFallback && !b.isPrimary)
+	if len(b.dependsOn) == 0 {
+		b.dependsOn = target.DependsOn
+	}
+	for key, value := range target.Labels {
+		if _, exists := b.labels[key]; !exists {
+			b.Label(key, value)
+		}
+	}
+	info := b.componentInfo()
+
+	// The replacement must stand in for the target wherever it was exported
+	// as another type than its own concrete type
+	for _, exportedType := range target.ExportedTypes {
+		if exportedType == target.InstanceType || containsType(info.ExportedTypes, exportedType) {
+			continue
+		}
+		if !info.InstanceType.AssignableTo(exportedType) {
+			return fmt.Errorf("cannot override '%s': type %s cannot be exported as %s",
+				target.Name, info.InstanceType, exportedType)
+		}
+		info.ExportedTypes = append(info.ExportedTypes, exportedType)
+	}
+
+	if existing, exists := c.componentByName[info.Name]; exists && existing != target {
+		return fmt.Errorf("component with name '%s' already registered: existing type %s, new type %s",
+			info.Name, existing.InstanceType, info.InstanceType)
+	}
+
+	delete(c.componentByName, target.Name)
+	c.componentByName[info.Name] = info
+	for i, component := range c.components {
+		if component == target {
+			c.components[i] = info
+			break
+		}
+	}
+	return nil
+}
+
+// findOverrideTargetUnsafe finds the registered component a builder replaces
+// without locking (assumes caller holds lock)
+func (c *Container) findOverrideTargetUnsafe(b *ObjectBuilder) (*ComponentInfo, error) {
+	if b.replacesName != "" {
+		target, exists := c.componentByName[b.replacesName]
+		if !exists {
+			return nil, fmt.Errorf("cannot override '%s': component not found", b.replacesName)
+		}
+		return target, nil
+	}
+
+	var candidates []*ComponentInfo
+	for _, info := range c.components {
+		if containsType(info.ExportedTypes, b.replacesType) {
+			candidates = append(candidates, info)
+		}
+	}
+	// Fallbacks lose to other components, as in autowiring
+	candidates = preferNonFallback(candidates)
+	var primaries []*ComponentInfo
+	for _, info := range candidates {
+		if info.IsPrimary {
+			primaries = append(primaries, info)
+		}
+	}
+
+	switch {
+	case len(candidates) == 1:
+		return candidates[0], nil
+	case len(candidates) == 0:
+		return nil, fmt.Errorf("cannot override type '%s': no component of that type found", b.replacesType)
+	case len(primaries) == 1:
+		return primaries[0], nil
+	default:
+		names := make([]string, len(candidates))
+		for i, info := range candidates {
+			names[i] = info.Name
+		}
+		return nil, fmt.Errorf("cannot override type '%s': ambiguous components %v (override by name)",
+			b.replacesType, names)
+	}
+}
+
+// containsType reports whether types contains t
+func containsType(types []reflect.Type, t reflect.Type) bool {
+	for _, candidate := range types {
+		if candidate == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package boot
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type overrideStore interface {
+	Load() string
+}
+
+type overrideProductionStore struct{}
+
+func (s *overrideProductionStore) Load() string {
+	return "production"
+}
+
+type overrideFakeStore struct{}
+
+func (s *overrideFakeStore) Load() string {
+	return "fake"
+}
+
+type overrideConsumer struct {
+	Store overrideStore `autowire:""`
+}
+
+func TestContainerOverrideByNameReplacesComponent(t *testing.T) {
+	container := NewContainer()
+	consumer := &overrideConsumer{}
+
+	container.Override("store", &overrideFakeStore{})
+	container.Object(&overrideProductionStore{}).Export((*overrideStore)(nil)).Name("store").Priority(10)
+	container.Object(consumer)
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run with override, got %v", err)
+	}
+	if got := consumer.Store.Load(); got != "fake" {
+		t.Fatalf("expected override to be injected, got %q", got)
+	}
+
+	component, err := container.GetByName("store")
+	if err != nil {
+		t.Fatalf("expected override to take the replaced name, got %v", err)
+	}
+	if _, ok := component.(*overrideFakeStore); !ok {
+		t.Fatalf("expected fake store under replaced name, got %T", component)
+	}
+	if _, err := container.GetByType(reflect.TypeOf(&overrideProductionStore{})); err == nil {
+		t.Fatal("expected replaced component to be removed")
+	}
+}
+
+func TestContainerOverrideByTypeReplacesPrimaryComponent(t *testing.T) {
+	container := NewContainer()
+	consumer := &overrideConsumer{}
+
+	container.Object(&overrideProductionStore{}).Export((*overrideStore)(nil)).Name("primary").Primary()
+	container.Object(&overrideProductionStore{}).Export((*overrideStore)(nil)).Name("secondary")
+	container.Object(consumer)
+	container.Override((*overrideStore)(nil), &overrideFakeStore{}).Name("fake")
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run with override, got %v", err)
+	}
+	if got := consumer.Store.Load(); got != "fake" {
+		t.Fatalf("expected override to inherit primary flag, got %q", got)
+	}
+	if _, err := container.GetByName("primary"); err == nil {
+		t.Fatal("expected replaced primary component to be removed")
+	}
+}
+
+func TestContainerOverrideByTypeSkipsFallbackComponent(t *testing.T) {
+	container := NewContainer()
+	consumer := &overrideConsumer{}
+
+	container.Object(&overrideProductionStore{}).Export((*overrideStore)(nil)).Name("default").Fallback()
+	container.Object(&overrideProductionStore{}).Export((*overrideStore)(nil)).Name("user")
+	container.Object(consumer)
+	container.Override((*overrideStore)(nil), &overrideFakeStore{}).Name("fake")
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected override to target the non-fallback component, got %v", err)
+	}
+	if got := consumer.Store.Load(); got != "fake" {
+		t.Fatalf("expected override to be injected, got %q", got)
+	}
+	if _, err := container.GetByName("user"); err == nil {
+		t.Fatal("expected replaced user component to be removed")
+	}
+	if _, err := container.GetByName("default"); err != nil {
+		t.Fatalf("expected fallback component to be kept, got %v", err)
+	}
+}
+
+func TestContainerOverrideFailsForMissingTarget(t *testing.T) {
+	container := NewContainer()
+	container.Object(&overrideProductionStore{}).Name("store")
+	container.Object(&overrideFakeStore{}).Replaces("stale")
+
+	err := container.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "cannot override 'stale': component not found") {
+		t.Fatalf("expected missing override target error, got %v", err)
+	}
+}
+
+func TestContainerOverrideRequiresExportedInterfaces(t *testing.T) {
+	container := NewContainer()
+	container.Object(&overrideProductionStore{}).Export((*overrideStore)(nil)).Name("store")
+	container.Override("store", &containerRunConsoleLogger{})
+
+	err := container.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "cannot be exported as") {
+		t.Fatalf("expected exported interface error, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package boot
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// ComponentPanicError reports a panic recovered from a lifecycle call of a
+// component
+type ComponentPanicError struct {
+	// Component is the name of the component that panicked
+	Component string
+	// Phase is the lifecycle call that panicked
+	Phase Phase
+	// Value is the value passed to panic
+	Value interface{}
+	// Stack is the stack trace of the panicking goroutine
+	Stack []byte
+}
+
+// Error implements error
+func (e *ComponentPanicError) Error() string {
+	return fmt.Sprintf("component '%s' panicked during %s: %v", e.Component, e.Phase, e.Value)
+}
+
+// Unwrap returns the panic value when it is an error
+func (e *ComponentPanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// callHook calls a lifecycle hook of a component, converting a panic into a
+// *ComponentPanicError so the normal failure path applies, and notifies the
+// lifecycle observers once it returned
+func (c *Container) callHook(info *ComponentInfo, phase Phase, hook func() error) (err error) {
+	start := time.Now()
+	defer func() {
+		c.notify(LifecycleEvent{Component: info.Name, Phase: phase, Duration: time.Since(start), Err: err})
+	}()
+	return c.recoverPanic(info, phase, hook)
+}
+
+// recoverPanic calls fn, converting a panic into a *ComponentPanicError
+// naming the component and phase
+func (c *Container) recoverPanic(info *ComponentInfo, phase Phase, fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicErr := &ComponentPanicError{Component: info.Name, Phase: phase, Value: r, Stack: debug.Stack()}
+			c.log().Errorf("ginject: %v\n%s", panicErr, panicErr.Stack)
+			err = panicErr
+		}
+	}()
+	return fn()
+}
@@ -0,0 +1,250 @@
+package boot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type panicRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *panicRecorder) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+type panickingInitComponent struct{}
+
+func (c *panickingInitComponent) Init(context.Context) error {
+	panic("init exploded")
+}
+
+type panickingStartComponent struct{}
+
+func (c *panickingStartComponent) Start(context.Context) error {
+	var values map[string]int
+	values["boom"] = 1
+	return nil
+}
+
+type panickingStopComponent struct {
+	recorder *panicRecorder
+}
+
+func (c *panickingStopComponent) Stop(context.Context) error {
+	c.recorder.record("panicking")
+	panic(errors.New("stop exploded"))
+}
+
+type recordingLifecycleComponent struct {
+	name     string
+	recorder *panicRecorder
+}
+
+func (c *recordingLifecycleComponent) Name() string {
+	return c.name
+}
+
+func (c *recordingLifecycleComponent) Start(context.Context) error {
+	c.recorder.record("start " + c.name)
+	return nil
+}
+
+func (c *recordingLifecycleComponent) Stop(context.Context) error {
+	c.recorder.record("stop " + c.name)
+	return nil
+}
+
+type panickingRunComponent struct {
+	panics int
+	runs   chan int
+}
+
+func (c *panickingRunComponent) Run(ctx context.Context) error {
+	c.runs <- 1
+	if c.panics > 0 {
+		c.panics--
+		panic("run exploded")
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func TestContainerRunRecoversPanicDuringInit(t *testing.T) {
+	container := NewContainer(WithLogger(&capturingLogger{}))
+	container.Object(&panickingInitComponent{}).Name("init-panic")
+
+	err := container.Run(context.Background())
+	var panicErr *ComponentPanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("expected ComponentPanicError, got %v", err)
+	}
+	if panicErr.Component != "init-panic" || panicErr.Phase != PhaseInit || panicErr.Value != "init exploded" {
+		t.Fatalf("expected attributed init panic, got %+v", panicErr)
+	}
+	if !strings.Contains(string(panicErr.Stack), "panickingInitComponent") {
+		t.Fatalf("expected stack trace of the panicking component, got %s", panicErr.Stack)
+	}
+	if container.State() != StateFailed {
+		t.Fatalf("expected failed state, got %s", container.State())
+	}
+}
+
+func TestContainerStartRollsBackStartedComponentsOnPanic(t *testing.T) {
+	recorder := &panicRecorder{}
+	logger := &capturingLogger{}
+	container := NewContainer(WithLogger(logger))
+	container.Object(&recordingLifecycleComponent{name: "first", recorder: recorder}).Priority(30).Primary()
+	container.Object(&recordingLifecycleComponent{name: "second", recorder: recorder}).Priority(20)
+	container.Object(&panickingStartComponent{}).Name("start-panic").Priority(10)
+	container.Object(&recordingLifecycleComponent{name: "never", recorder: recorder}).Priority(0)
+
+	err := container.Run(context.Background())
+	var panicErr *ComponentPanicError
+	if !errors.As(err, &panicErr) || panicErr.Component != "start-panic" || panicErr.Phase != PhaseStart {
+		t.Fatalf("expected start panic of 'start-panic', got %v", err)
+	}
+	if !strings.Contains(err.Error(), "startup failed for 'start-panic'") {
+		t.Fatalf("expected startup failure attribution, got %v", err)
+	}
+
+	expected := "start first,start second,stop second,stop first"
+	if got := strings.Join(recorder.events, ","); got != expected {
+		t.Fatalf("expected events %s, got %s", expected, got)
+	}
+	if container.State() != StateFailed {
+		t.Fatalf("expected failed state, got %s", container.State())
+	}
+	if len(logger.error) == 0 || !strings.Contains(logger.error[0], "panicked during start") {
+		t.Fatalf("expected panic to be logged, got %v", logger.error)
+	}
+}
+
+func TestContainerStopContinuesAfterPanic(t *testing.T) {
+	recorder := &panicRecorder{}
+	container := NewContainer(WithLogger(&capturingLogger{}))
+	container.Object(&recordingLifecycleComponent{name: "database", recorder: recorder}).Priority(10)
+	container.Object(&panickingStopComponent{recorder: recorder}).Name("stop-panic")
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	err := container.Stop(context.Background())
+
+	var panicErr *ComponentPanicError
+	if !errors.As(err, &panicErr) || panicErr.Phase != PhaseStop || panicErr.Component != "stop-panic" {
+		t.Fatalf("expected stop panic of 'stop-panic', got %v", err)
+	}
+	if err.Error() != "shutdown failed for 'stop-panic': component 'stop-panic' panicked during stop: stop exploded" {
+		t.Fatalf("unexpected error message %q", err)
+	}
+	if !strings.Contains(errors.Unwrap(panicErr).Error(), "stop exploded") {
+		t.Fatalf("expected panic error value to be unwrapped, got %v", errors.Unwrap(panicErr))
+	}
+	if got := strings.Join(recorder.events, ","); got != "start database,panicking,stop database" {
+		t.Fatalf("expected remaining components to be stopped, got %s", got)
+	}
+	if container.State() != StateStopped {
+		t.Fatalf("expected stopped state, got %s", container.State())
+	}
+}
+
+func TestRunnerRestartsAfterPanic(t *testing.T) {
+	component := &panickingRunComponent{panics: 1, runs: make(chan int, 10)}
+	container := NewContainer(WithLogger(&capturingLogger{}))
+	container.Object(component).Name("worker").RestartPolicy(RestartPolicy{Mode: RestartOnFailure, Backoff: time.Millisecond})
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-component.runs:
+		case <-time.After(time.Second):
+			t.Fatalf("expected panicking runner to be restarted, started %d times", i)
+		}
+	}
+	if err := container.Stop(context.Background()); err != nil {
+		t.Fatalf("expected clean stop, got %v", err)
+	}
+}
+
+type panickingInjectComponent struct{}
+
+func (c *panickingInjectComponent) Inject() {
+	panic("inject exploded")
+}
+
+type panickingPostProcessor struct{}
+
+func (p *panickingPostProcessor) BeforeInit(info *ComponentInfo, instance interface{}) (interface{}, error) {
+	return instance, nil
+}
+
+func (p *panickingPostProcessor) AfterInit(info *ComponentInfo, instance interface{}) (interface{}, error) {
+	panic("post-processor exploded")
+}
+
+func TestContainerRunRecoversPanicsDuringWiring(t *testing.T) {
+	tests := []struct {
+		name      string
+		register  func(c *Container)
+		component string
+		phase     Phase
+	}{
+		{
+			name: "inject",
+			register: func(c *Container) {
+				c.Object(&panickingInjectComponent{}).Name("client").Inject()
+			},
+			component: "client",
+			phase:     PhaseInject,
+		},
+		{
+			name: "decorate",
+			register: func(c *Container) {
+				c.Object(&containerRunConsoleLogger{}).Name("console").Export((*containerRunLogger)(nil))
+				c.Decorate((*containerRunLogger)(nil), func(inner containerRunLogger) containerRunLogger {
+					panic("decorator exploded")
+				})
+			},
+			component: "console",
+			phase:     PhaseDecorate,
+		},
+		{
+			name: "post-process",
+			register: func(c *Container) {
+				c.Object(&panickingPostProcessor{}).Name("processor")
+				c.Object(&containerRunConsoleLogger{}).Name("console")
+			},
+			component: "processor",
+			phase:     PhaseAfterInit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			container := NewContainer(WithLogger(&capturingLogger{}))
+			tt.register(container)
+
+			err := container.Run(context.Background())
+			var panicErr *ComponentPanicError
+			if !errors.As(err, &panicErr) {
+				t.Fatalf("expected ComponentPanicError, got %v", err)
+			}
+			if panicErr.Component != tt.component || panicErr.Phase != tt.phase {
+				t.Fatalf("expected panic of '%s' during %s, got %+v", tt.component, tt.phase, panicErr)
+			}
+			if container.State() != StateFailed {
+				t.Fatalf("expected failed state, got %s", container.State())
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package boot
+
+import (
+	"context"
+	"errors"
+)
+
+// runPhase calls fn for every component in the given order. In parallel
+// lifecycle mode, components of equal priority without a dependency relation
+// run concurrently while dependencies still complete first.
+func (c *Container) runPhase(ctx context.Context, components []*ComponentInfo, fn func(context.Context, *ComponentInfo) error) error {
+	if !c.parallel {
+		for _, info := range components {
+			if err := fn(ctx, info); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for start := 0; start < len(components); {
+		end := start + 1
+		for end < len(components) && components[end].Priority == components[start].Priority {
+			end++
+		}
+		if err := c.runLevelParallel(ctx, components[start:end], fn); err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
+
+// ErrSiblingFailed is returned, possibly wrapped, by an Init or Start hook
+// that gave up because SiblingFailed was closed
+var ErrSiblingFailed = errors.New("sibling component failed")
+
+type siblingFailedKey struct{}
+
+// SiblingFailed returns a channel that is closed when another component of
+// the same parallel lifecycle level fails, so a long Init or Start can give
+// up early by returning ErrSiblingFailed. It returns nil, which blocks forever
+// in a select, outside a parallel level.
+func SiblingFailed(ctx context.Context) <-chan struct{} {
+	failed, _ := ctx.Value(siblingFailedKey{}).(chan struct{})
+	return failed
+}
+
+// runLevelParallel runs fn concurrently for components of equal priority,
+// bounded by the parallel limit. The first failure closes the SiblingFailed
+// channel of the running siblings and prevents the remaining ones from
+// starting. The context itself is never cancelled by the level, so components
+// may keep it for background work like the context of a sequential lifecycle.
+func (c *Container) runLevelParallel(ctx context.Context, level []*ComponentInfo, fn func(context.Context, *ComponentInfo) error) error {
+	if len(level) == 1 {
+		return fn(ctx, level[0])
+	}
+
+	// Build the dependency edges between components of this level
+	index := make(map[string]int, len(level))
+	for i, info := range level {
+		index[info.Name] = i
+	}
+	waitingOn := make([]int, len(level))
+	dependents := make([][]int, len(level))
+	for i, info := range level {
+		seen := make(map[int]bool)
+		for _, name := range dependencyNames(info) {
+			if j, exists := index[name]; exists && j != i && !seen[j] {
+				seen[j] = true
+				waitingOn[i]++
+				dependents[j] = append(dependents[j], i)
+			}
+		}
+	}
+
+	limit := c.parallelLimit
+	if limit <= 0 {
+		limit = len(level)
+	}
+
+	siblingFailed := make(chan struct{})
+	ctx = context.WithValue(ctx, siblingFailedKey{}, siblingFailed)
+
+	type result struct {
+		index int
+		err   error
+	}
+	results := make(chan result)
+	errs := make([]error, len(level))
+	started := make([]bool, len(level))
+	launch := func(i int) {
+		started[i] = true
+		go func() {
+			results <- result{index: i, err: fn(ctx, level[i])}
+		}()
+	}
+
+	running := 0
+	failed := false
+	for {
+		if !failed {
+			for i := range level {
+				if running >= limit {
+					break
+				}
+				if !started[i] && waitingOn[i] == 0 {
+					launch(i)
+					running++
+				}
+			}
+			// A dependency cycle leaves nothing ready; break it in registration order
+			if running == 0 {
+				for i := range level {
+					if !started[i] {
+						launch(i)
+						running++
+						break
+					}
+				}
+			}
+		}
+		if running == 0 {
+			break
+		}
+
+		r := <-results
+		running--
+		if r.err != nil {
+			errs[r.index] = r.err
+			if !failed {
+				failed = true
+				close(siblingFailed)
+			}
+			continue
+		}
+		for _, dependent := range dependents[r.index] {
+			waitingOn[dependent]--
+		}
+	}
+
+	return firstError(errs)
+}
+
+// dependencyNames returns the names of the components a component depends
+// on, through injection or DependsOn
+func dependencyNames(info *ComponentInfo) []string {
+	names := append([]string{}, info.DependsOn...)
+	for _, dependency := range info.Dependencies {
+		names = append(names, dependency.Components...)
+	}
+	return names
+}
+
+// firstError returns the error of the earliest component in order, preferring
+// real failures over siblings that gave up or were cancelled
+func firstError(errs []error) error {
+	var cancelled error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ErrSiblingFailed) && !errors.Is(err, context.Canceled) {
+			return err
+		}
+		if cancelled == nil {
+			cancelled = err
+		}
+	}
+	return cancelled
+}
@@ -0,0 +1,197 @@
+package boot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type barrierComponent struct {
+	barrier *sync.WaitGroup
+	err     error
+}
+
+func (c *barrierComponent) Init(context.Context) error {
+	c.barrier.Done()
+	done := make(chan struct{})
+	go func() {
+		c.barrier.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		c.err = errors.New("siblings did not run concurrently")
+	}
+	return c.err
+}
+
+type barrierComponentB struct{ barrierComponent }
+
+type barrierComponentC struct{ barrierComponent }
+
+func TestParallelLifecycleInitializesIndependentComponentsConcurrently(t *testing.T) {
+	barrier := &sync.WaitGroup{}
+	barrier.Add(3)
+
+	container := NewContainer(WithParallelLifecycle(0))
+	container.Object(&barrierComponent{barrier: barrier})
+	container.Object(&barrierComponentB{barrierComponent{barrier: barrier}})
+	container.Object(&barrierComponentC{barrierComponent{barrier: barrier}})
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected concurrent initialization, got %v", err)
+	}
+	container.Stop(context.Background())
+}
+
+type orderedDependency struct {
+	started atomic.Bool
+}
+
+func (c *orderedDependency) Start(context.Context) error {
+	time.Sleep(10 * time.Millisecond)
+	c.started.Store(true)
+	return nil
+}
+
+type orderedDependent struct {
+	Dependency *orderedDependency `autowire:""`
+	sawStarted bool
+}
+
+func (c *orderedDependent) Start(context.Context) error {
+	c.sawStarted = c.Dependency.started.Load()
+	return nil
+}
+
+func TestParallelLifecyclePreservesDependencyOrder(t *testing.T) {
+	container := NewContainer(WithParallelLifecycle(0))
+	dependent := &orderedDependent{}
+	container.Object(dependent)
+	container.Object(&orderedDependency{})
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	defer container.Stop(context.Background())
+
+	if !dependent.sawStarted {
+		t.Fatal("expected dependency to start before its dependent")
+	}
+}
+
+type concurrencyCounter struct {
+	current atomic.Int32
+	max     atomic.Int32
+}
+
+type countingComponent struct {
+	counter *concurrencyCounter
+}
+
+func (c *countingComponent) Start(context.Context) error {
+	current := c.counter.current.Add(1)
+	if current > c.counter.max.Load() {
+		c.counter.max.Store(current)
+	}
+	time.Sleep(5 * time.Millisecond)
+	c.counter.current.Add(-1)
+	return nil
+}
+
+type countingComponentB struct{ countingComponent }
+
+type countingComponentC struct{ countingComponent }
+
+func TestParallelLifecycleRespectsLimit(t *testing.T) {
+	counter := &concurrencyCounter{}
+	container := NewContainer(WithParallelLifecycle(1))
+	container.Object(&countingComponent{counter: counter})
+	container.Object(&countingComponentB{countingComponent{counter: counter}})
+	container.Object(&countingComponentC{countingComponent{counter: counter}})
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	defer container.Stop(context.Background())
+
+	if max := counter.max.Load(); max != 1 {
+		t.Fatalf("expected at most 1 concurrent start, got %d", max)
+	}
+}
+
+type cancellableStartComponent struct {
+	cancelled atomic.Bool
+	ctx       context.Context
+}
+
+func (c *cancellableStartComponent) Start(ctx context.Context) error {
+	c.ctx = ctx
+	select {
+	case <-SiblingFailed(ctx):
+		c.cancelled.Store(true)
+		return ErrSiblingFailed
+	case <-time.After(time.Second):
+		return nil
+	}
+}
+
+type failingParallelStartComponent struct{}
+
+func (c *failingParallelStartComponent) Start(context.Context) error {
+	time.Sleep(5 * time.Millisecond)
+	return errors.New("port in use")
+}
+
+func TestParallelLifecycleCancelsSiblingsOnFailure(t *testing.T) {
+	sibling := &cancellableStartComponent{}
+	container := NewContainer(WithParallelLifecycle(0))
+	container.Object(sibling).Name("sibling")
+	container.Object(&failingParallelStartComponent{}).Name("server")
+
+	err := container.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "startup failed for 'server': port in use") {
+		t.Fatalf("expected the real failure to be reported, got %v", err)
+	}
+	if !sibling.cancelled.Load() {
+		t.Fatal("expected running sibling to be notified of the failure")
+	}
+	if err := sibling.ctx.Err(); err != nil {
+		t.Fatalf("expected the sibling context to stay uncancelled, got %v", err)
+	}
+}
+
+type contextKeepingComponent struct {
+	ctx context.Context
+}
+
+func (c *contextKeepingComponent) Start(ctx context.Context) error {
+	c.ctx = ctx
+	return nil
+}
+
+type contextKeepingComponentB struct{ contextKeepingComponent }
+
+func TestParallelLifecycleKeepsContextAliveAfterLevel(t *testing.T) {
+	first := &contextKeepingComponent{}
+	second := &contextKeepingComponentB{}
+	container := NewContainer(WithParallelLifecycle(0))
+	container.Object(first).Name("first")
+	container.Object(second).Name("second")
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	defer container.Stop(context.Background())
+
+	for _, ctx := range []context.Context{first.ctx, second.ctx} {
+		if err := ctx.Err(); err != nil {
+			t.Fatalf("expected Start context to stay alive after Run, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package boot
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// injectionPlans caches the injectionPlan of every struct type seen so far
+var injectionPlans sync.Map // map[reflect.Type]*injectionPlan
+
+// injectionPlan lists the fields of a struct type the container has to visit,
+// with their autowire tags parsed once
+type injectionPlan struct {
+	fields []fieldPlan
+	// tagged counts the tagged fields, to size the recorded dependencies
+	tagged int
+}
+
+// fieldPlan describes a tagged field to resolve or an untagged nested struct
+// or struct pointer to scan
+type fieldPlan struct {
+	index     int
+	name      string
+	exported  bool
+	tagged    bool
+	qualifier string
+	optional  bool
+	allocate  bool
+	selectors []labelSelector
+	// selectorErr reports an invalid label qualifier when the field is injected
+	selectorErr error
+}
+
+// planFor returns the injection plan of a struct type, compiling it on first use
+func planFor(t reflect.Type) *injectionPlan {
+	if plan, ok := injectionPlans.Load(t); ok {
+		return plan.(*injectionPlan)
+	}
+	plan, _ := injectionPlans.LoadOrStore(t, compilePlan(t))
+	return plan.(*injectionPlan)
+}
+
+// injectionPlanSize returns the number of tagged fields declared directly by
+// a component, or zero when it is not a struct pointer
+func injectionPlanSize(component interface{}) int {
+	t := reflect.TypeOf(component)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return 0
+	}
+	return planFor(t.Elem()).tagged
+}
+
+// compilePlan walks the fields of a struct type. Untagged fields are only kept
+// when they are structs or struct pointers declaring autowire fields, since
+// scanning anything else cannot inject a dependency.
+func compilePlan(t reflect.Type) *injectionPlan {
+	plan := &injectionPlan{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fp := fieldPlan{index: i, name: field.Name, exported: field.IsExported()}
+
+		tag, tagged := field.Tag.Lookup("autowire")
+		if !tagged {
+			nested := field.Type
+			if nested.Kind() == reflect.Ptr {
+				nested = nested.Elem()
+			}
+			if !declaresAutowireFields(nested, map[reflect.Type]bool{}) {
+				continue
+			}
+			plan.fields = append(plan.fields, fp)
+			continue
+		}
+
+		fp.tagged = true
+		plan.tagged++
+		fp.qualifier = tag
+		fp.allocate = tag == autowireNew
+		fp.optional = tag == "optional" || tag == "?" || strings.HasSuffix(tag, ",optional")
+		if isLabelQualifier(tag) {
+			fp.selectors, fp.selectorErr = parseLabelSelectors(tag)
+		}
+		plan.fields = append(plan.fields, fp)
+	}
+	return plan
+}
@@ -0,0 +1,80 @@
+package boot
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type benchConfig struct {
+	Address string
+}
+
+type benchDependencies struct {
+	Logger containerRunLogger `autowire:""`
+}
+
+type benchService struct {
+	Logger   containerRunLogger `autowire:""`
+	Config   *benchConfig       `autowire:"config"`
+	Metrics  containerRunLogger `autowire:"metrics,optional"`
+	Optional *benchConfig       `autowire:"?"`
+	Nested   benchDependencies
+	Counter  int
+	Label    string
+}
+
+func TestPlanForCachesParsedFields(t *testing.T) {
+	serviceType := reflect.TypeOf(benchService{})
+	plan := planFor(serviceType)
+	if planFor(serviceType) != plan {
+		t.Fatal("expected the plan of a type to be compiled once")
+	}
+
+	names := make([]string, len(plan.fields))
+	for i, fp := range plan.fields {
+		names[i] = fp.name
+	}
+	// Counter and Label cannot hold dependencies and are left out
+	if got := strings.Join(names, ","); got != "Logger,Config,Metrics,Optional,Nested" {
+		t.Fatalf("expected tagged and nested fields only, got %s", got)
+	}
+	if plan.tagged != 4 || !plan.fields[2].optional || plan.fields[1].qualifier != "config" || plan.fields[4].tagged {
+		t.Fatalf("expected parsed tags, got %+v", plan.fields)
+	}
+}
+
+func newBenchContainer(components int) *Container {
+	container := NewContainer(WithLogger(&capturingLogger{}))
+	container.Object(&containerRunConsoleLogger{}).Export((*containerRunLogger)(nil)).Name("logger")
+	container.Object(&benchConfig{}).Name("config")
+	for i := 0; i < components; i++ {
+		builder := container.Object(&benchService{}).Name(fmt.Sprintf("service-%d", i))
+		if i == 0 {
+			builder.Primary()
+		}
+	}
+	return container
+}
+
+func benchmarkContainerRun(b *testing.B, components int) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		container := newBenchContainer(components)
+		b.StartTimer()
+		if err := container.Run(context.Background()); err != nil {
+			b.Fatalf("expected container to run, got %v", err)
+		}
+	}
+}
+
+func BenchmarkContainerRun1000(b *testing.B) {
+	benchmarkContainerRun(b, 1000)
+}
+
+func BenchmarkContainerRun5000(b *testing.B) {
+	benchmarkContainerRun(b, 5000)
+}
@@ -0,0 +1,70 @@
+package boot
+
+import "time"
+
+// RestartMode selects when the container restarts the loop of a Runnable component
+type RestartMode int
+
+const (
+	// RestartNever treats an error returned by Run as a fatal application error
+	RestartNever RestartMode = iota
+	// RestartOnFailure restarts Run after it returns an error
+	RestartOnFailure
+	// RestartAlways restarts Run whenever it returns before the container stops
+	RestartAlways
+)
+
+// DefaultRestartBackoff is the delay before the first restart when a
+// RestartPolicy does not set one
+const DefaultRestartBackoff = time.Second
+
+// DefaultRestartResetAfter is how long a run must last before its failure
+// no longer counts as consecutive when a RestartPolicy does not set ResetAfter
+const DefaultRestartResetAfter = time.Minute
+
+// RestartPolicy describes how the container restarts a Runnable component.
+// The zero value never restarts.
+type RestartPolicy struct {
+	Mode RestartMode
+	// MaxAttempts limits the number of restarts after consecutive failures;
+	// zero means unlimited. Once exhausted, the failure escalates to a fatal
+	// application error.
+	MaxAttempts int
+	// Backoff is the delay before the first restart, doubled after each
+	// consecutive failure (defaults to DefaultRestartBackoff)
+	Backoff time.Duration
+	// MaxBackoff caps the delay between restarts; zero means no cap
+	MaxBackoff time.Duration
+	// ResetAfter is how long a run must stay up for the failure count and the
+	// backoff to start over (defaults to DefaultRestartResetAfter)
+	ResetAfter time.Duration
+}
+
+// healthy reports whether a run that lasted the given duration resets the
+// consecutive failure count
+func (p RestartPolicy) healthy(lasted time.Duration) bool {
+	resetAfter := p.ResetAfter
+	if resetAfter <= 0 {
+		resetAfter = DefaultRestartResetAfter
+	}
+	return lasted >= resetAfter
+}
+
+// delay returns the wait before the next restart after the given number of
+// consecutive failures
+func (p RestartPolicy) delay(failures int) time.Duration {
+	delay := p.Backoff
+	if delay <= 0 {
+		delay = DefaultRestartBackoff
+	}
+	for i := 1; i < failures; i++ {
+		delay *= 2
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,124 @@
+package boot
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// runner tracks the goroutine running a Runnable component
+type runner struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// startRunners launches every Runnable component in its own goroutine. The
+// runners outlive ctx and are only cancelled by Stop.
+func (c *Container) startRunners(ctx context.Context, components []*ComponentInfo) {
+	c.runners = make(map[*ComponentInfo]*runner)
+	for _, info := range components {
+		runnable, ok := info.Instance.(Runnable)
+		if !ok {
+			continue
+		}
+
+		runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		r := &runner{cancel: cancel, done: make(chan struct{})}
+		c.runners[info] = r
+
+		go c.supervise(runCtx, info, runnable, r)
+	}
+}
+
+// supervise runs a Runnable, restarting it according to the component's
+// RestartPolicy, and reports an unrecoverable error as a container failure
+func (c *Container) supervise(ctx context.Context, info *ComponentInfo, runnable Runnable, r *runner) {
+	defer close(r.done)
+
+	policy := info.RestartPolicy
+	failures := 0
+	for {
+		started := time.Now()
+		err := c.callHook(info, PhaseRun, func() error { return runnable.Run(ctx) })
+		if ctx.Err() != nil {
+			// Cancelled by Stop, any return value is expected
+			return
+		}
+
+		if err == nil {
+			if policy.Mode != RestartAlways {
+				c.log().Infof("ginject: runner '%s' finished", info.Name)
+				return
+			}
+			failures = 0
+			c.log().Infof("ginject: runner '%s' finished, restarting in %s", info.Name, policy.delay(1))
+		} else {
+			if policy.Mode == RestartNever {
+				c.log().Errorf("ginject: runner '%s' failed: %v", info.Name, err)
+				c.fail(fmt.Errorf("runner '%s' failed: %w", info.Name, err))
+				return
+			}
+			if policy.healthy(time.Since(started)) {
+				// Only consecutive failures count towards MaxAttempts and the backoff
+				failures = 0
+			}
+			failures++
+			if policy.MaxAttempts > 0 && failures > policy.MaxAttempts {
+				c.log().Errorf("ginject: runner '%s' failed after %d restarts: %v", info.Name, policy.MaxAttempts, err)
+				c.fail(fmt.Errorf("runner '%s' failed after %d restarts: %w", info.Name, policy.MaxAttempts, err))
+				return
+			}
+			c.log().Warnf("ginject: runner '%s' failed: %v, restarting in %s (attempt %d)",
+				info.Name, err, policy.delay(failures), failures)
+		}
+
+		timer := time.NewTimer(policy.delay(max(failures, 1)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// stopRunner cancels the runner of a component and waits for it to return
+// for at most the stop timeout
+func (c *Container) stopRunner(info *ComponentInfo) error {
+	r, exists := c.runners[info]
+	if !exists {
+		return nil
+	}
+	delete(c.runners, info)
+
+	r.cancel()
+	if c.stopTimeout <= 0 {
+		<-r.done
+		return nil
+	}
+
+	timer := time.NewTimer(c.stopTimeout)
+	defer timer.Stop()
+	select {
+	case <-r.done:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("runner '%s' did not stop within %s", info.Name, c.stopTimeout)
+	}
+}
+
+// fail records the first fatal component error
+func (c *Container) fail(err error) {
+	select {
+	case c.failures <- err:
+	default:
+		// A failure is already pending, keep the first one
+	}
+}
+
+// Failed returns a channel that receives the first fatal error reported by a
+// component after startup, such as a Runnable returning an error. Run
+// requests shutdown when it receives from this channel.
+func (c *Container) Failed() <-chan error {
+	return c.failures
+}
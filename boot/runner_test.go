@@ -0,0 +1,267 @@
+package boot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type loopComponent struct {
+	started chan struct{}
+	stopped bool
+	exited  bool
+}
+
+func (c *loopComponent) Run(ctx context.Context) error {
+	close(c.started)
+	<-ctx.Done()
+	c.exited = true
+	return ctx.Err()
+}
+
+func (c *loopComponent) Stop(context.Context) error {
+	c.stopped = true
+	return nil
+}
+
+func TestContainerRunsRunnableUntilStop(t *testing.T) {
+	container := NewContainer()
+	component := &loopComponent{started: make(chan struct{})}
+	container.Object(component)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := container.Run(ctx); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	cancel()
+
+	select {
+	case <-component.started:
+	case <-time.After(time.Second):
+		t.Fatal("expected runner to be launched after startup")
+	}
+
+	if err := container.Stop(context.Background()); err != nil {
+		t.Fatalf("expected container to stop, got %v", err)
+	}
+	if !component.exited {
+		t.Fatal("expected Stop to cancel the runner and wait for it")
+	}
+	if !component.stopped {
+		t.Fatal("expected Stop to stop the runnable component")
+	}
+}
+
+type failingLoopComponent struct{}
+
+func (c *failingLoopComponent) Run(context.Context) error {
+	return errors.New("connection lost")
+}
+
+func TestContainerReportsRunnableFailure(t *testing.T) {
+	container := NewContainer(WithLogger(&capturingLogger{}))
+	container.Object(&failingLoopComponent{}).Name("consumer")
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	defer container.Stop(context.Background())
+
+	select {
+	case err := <-container.Failed():
+		if !strings.Contains(err.Error(), "runner 'consumer' failed: connection lost") {
+			t.Fatalf("expected runner failure, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected runner failure to be reported")
+	}
+}
+
+type stuckLoopComponent struct {
+	release chan struct{}
+}
+
+func (c *stuckLoopComponent) Run(context.Context) error {
+	<-c.release
+	return nil
+}
+
+func TestContainerStopTimesOutWaitingForRunnable(t *testing.T) {
+	container := NewContainer(WithStopTimeout(10 * time.Millisecond))
+	component := &stuckLoopComponent{release: make(chan struct{})}
+	defer close(component.release)
+	container.Object(component).Name("stuck")
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+
+	err := container.Stop(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "runner 'stuck' did not stop within 10ms") {
+		t.Fatalf("expected stop timeout error, got %v", err)
+	}
+}
+
+type flakyLoopComponent struct {
+	failures int
+	runs     chan int
+}
+
+func (c *flakyLoopComponent) Run(ctx context.Context) error {
+	c.runs <- 1
+	if c.failures > 0 {
+		c.failures--
+		return errors.New("flaky")
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func TestContainerRestartsRunnableOnFailure(t *testing.T) {
+	logger := &capturingLogger{}
+	container := NewContainer(WithLogger(logger))
+	component := &flakyLoopComponent{failures: 2, runs: make(chan int, 10)}
+	container.Object(component).RestartPolicy(RestartPolicy{
+		Mode:        RestartOnFailure,
+		MaxAttempts: 3,
+		Backoff:     time.Millisecond,
+	})
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		select {
+		case <-component.runs:
+		case <-time.After(time.Second):
+			t.Fatalf("expected runner to be started 3 times, got %d", i)
+		}
+	}
+	if err := container.Stop(context.Background()); err != nil {
+		t.Fatalf("expected container to stop, got %v", err)
+	}
+
+	select {
+	case err := <-container.Failed():
+		t.Fatalf("expected restarts to recover the runner, got failure %v", err)
+	default:
+	}
+}
+
+func TestContainerEscalatesWhenRestartBudgetIsExhausted(t *testing.T) {
+	container := NewContainer(WithLogger(&capturingLogger{}))
+	component := &flakyLoopComponent{failures: 100, runs: make(chan int, 10)}
+	container.Object(component).Name("consumer").RestartPolicy(RestartPolicy{
+		Mode:        RestartOnFailure,
+		MaxAttempts: 2,
+		Backoff:     time.Millisecond,
+	})
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	defer container.Stop(context.Background())
+
+	select {
+	case err := <-container.Failed():
+		if !strings.Contains(err.Error(), "runner 'consumer' failed after 2 restarts: flaky") {
+			t.Fatalf("expected exhausted restart budget error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected exhausted restart budget to be reported")
+	}
+	if runs := len(component.runs); runs != 3 {
+		t.Fatalf("expected initial run plus 2 restarts, got %d runs", runs)
+	}
+}
+
+type slowFailingLoopComponent struct {
+	lasts time.Duration
+	runs  chan int
+}
+
+func (c *slowFailingLoopComponent) Run(context.Context) error {
+	c.runs <- 1
+	time.Sleep(c.lasts)
+	return errors.New("flaky")
+}
+
+func TestContainerResetsRestartBudgetAfterHealthyRun(t *testing.T) {
+	container := NewContainer(WithLogger(&capturingLogger{}))
+	component := &slowFailingLoopComponent{lasts: 20 * time.Millisecond, runs: make(chan int, 100)}
+	container.Object(component).Name("consumer").RestartPolicy(RestartPolicy{
+		Mode:        RestartOnFailure,
+		MaxAttempts: 1,
+		Backoff:     time.Millisecond,
+		ResetAfter:  10 * time.Millisecond,
+	})
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	for i := 0; i < 4; i++ {
+		select {
+		case <-component.runs:
+		case err := <-container.Failed():
+			t.Fatalf("expected failures after healthy runs to be restarted, got %v", err)
+		case <-time.After(time.Second):
+			t.Fatalf("expected runner to be restarted, got %d runs", i)
+		}
+	}
+	if err := container.Stop(context.Background()); err != nil {
+		t.Fatalf("expected container to stop, got %v", err)
+	}
+}
+
+func TestRestartPolicyHealthyDefaultsResetAfter(t *testing.T) {
+	if (RestartPolicy{}).healthy(DefaultRestartResetAfter - time.Second) {
+		t.Fatal("expected a short run to count as a consecutive failure")
+	}
+	if !(RestartPolicy{}).healthy(DefaultRestartResetAfter) {
+		t.Fatal("expected a run of DefaultRestartResetAfter to reset the failure count")
+	}
+	if !(RestartPolicy{ResetAfter: time.Second}).healthy(time.Second) {
+		t.Fatal("expected ResetAfter to override the default")
+	}
+}
+
+type finishingLoopComponent struct {
+	runs chan int
+}
+
+func (c *finishingLoopComponent) Run(context.Context) error {
+	c.runs <- 1
+	return nil
+}
+
+func TestContainerRestartAlwaysRestartsFinishedRunnable(t *testing.T) {
+	container := NewContainer(WithLogger(&capturingLogger{}))
+	component := &finishingLoopComponent{runs: make(chan int, 100)}
+	container.Object(component).RestartPolicy(RestartPolicy{Mode: RestartAlways, Backoff: time.Millisecond})
+
+	if err := container.Run(context.Background()); err != nil {
+		t.Fatalf("expected container to run, got %v", err)
+	}
+	defer container.Stop(context.Background())
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-component.runs:
+		case <-time.After(time.Second):
+			t.Fatal("expected finished runner to be restarted")
+		}
+	}
+}
+
+func TestRestartPolicyDelayDoublesUpToMaxBackoff(t *testing.T) {
+	policy := RestartPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	for i, want := range expected {
+		if got := policy.delay(i + 1); got != want {
+			t.Fatalf("expected delay %s after %d failures, got %s", want, i+1, got)
+		}
+	}
+}
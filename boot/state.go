@@ -0,0 +1,57 @@
+package boot
+
+import "fmt"
+
+// State is the lifecycle state of a container
+type State int
+
+const (
+	// StateCreated is the state of a container that has not been run yet
+	StateCreated State = iota
+	// StateStarting covers registration, injection, Init and Start
+	StateStarting
+	// StateRunning is the state after every component has started
+	StateRunning
+	// StateStopping is entered as soon as Stop is called on a running container
+	StateStopping
+	// StateStopped is the state after Stop has completed
+	StateStopped
+	// StateFailed is the state of a container whose startup failed
+	StateFailed
+)
+
+var stateNames = map[State]string{
+	StateCreated:  "created",
+	StateStarting: "starting",
+	StateRunning:  "running",
+	StateStopping: "stopping",
+	StateStopped:  "stopped",
+	StateFailed:   "failed",
+}
+
+// String returns the lowercase name of the state
+func (s State) String() string {
+	if name, exists := stateNames[s]; exists {
+		return name
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// MarshalText encodes the state as its name
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// State returns the current lifecycle state of the container
+func (c *Container) State() State {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	return c.state
+}
+
+// setState records a lifecycle state transition
+func (c *Container) setState(state State) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.state = state
+}
@@ -0,0 +1,495 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const bootPath = "github.com/esclipez/ginject/boot"
+
+// config holds the command line options
+type config struct {
+	dir        string
+	output     string
+	typeName   string
+	unexported bool
+}
+
+func (c config) outputPath() string {
+	if filepath.IsAbs(c.output) {
+		return c.output
+	}
+	return filepath.Join(c.dir, c.output)
+}
+
+// registration is a boot.Object call chain found in the package
+type registration struct {
+	pos       token.Pos
+	expr      string
+	typ       types.Type
+	name      string
+	nameSet   bool
+	priority  int64
+	exported  []types.Type
+	primary   bool
+	fallback  bool
+	dependsOn []string
+	inject    []string
+}
+
+// diagnostics collects positioned errors
+type diagnostics struct {
+	fset *token.FileSet
+	errs []error
+}
+
+func (d *diagnostics) errorf(pos token.Pos, format string, args ...interface{}) {
+	d.errs = append(d.errs, fmt.Errorf("%s: %s", d.fset.Position(pos), fmt.Sprintf(format, args...)))
+}
+
+func (d *diagnostics) err() error {
+	return errors.Join(d.errs...)
+}
+
+// loadPackage type-checks the package in cfg.dir. The output file is
+// replaced by a stub declaring the generated type, so neither a stale file
+// nor code calling the not yet generated constructor breaks type checking.
+func loadPackage(cfg config) (*packages.Package, error) {
+	outputPath, err := filepath.Abs(cfg.outputPath())
+	if err != nil {
+		return nil, err
+	}
+	packageName, err := outputPackageName(cfg.dir, outputPath)
+	if err != nil {
+		return nil, err
+	}
+	overlay := map[string][]byte{outputPath: stubSource(cfg, packageName)}
+
+	loadConfig := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+		Dir:     cfg.dir,
+		Overlay: overlay,
+	}
+	pkgs, err := packages.Load(loadConfig, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load package: %w", err)
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected one package in %s, found %d", cfg.dir, len(pkgs))
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		errs := make([]error, len(pkg.Errors))
+		for i, err := range pkg.Errors {
+			errs[i] = err
+		}
+		return nil, errors.Join(errs...)
+	}
+	return pkg, nil
+}
+
+// outputPackageName returns the package clause of the existing output file
+// or, on the first run, of the first other Go file in dir
+func outputPackageName(dir, outputPath string) (string, error) {
+	files := []string{outputPath}
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", err
+	}
+	for _, match := range matches {
+		if !strings.HasSuffix(match, "_test.go") {
+			files = append(files, match)
+		}
+	}
+	for _, path := range files {
+		source, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, source, parser.PackageClauseOnly)
+		if err != nil {
+			return "", err
+		}
+		return file.Name.Name, nil
+	}
+	return "", fmt.Errorf("no Go files in %s", dir)
+}
+
+// stubSource declares the API of the generated file without any wiring
+func stubSource(cfg config, packageName string) []byte {
+	return []byte(fmt.Sprintf(`package %s
+
+import "context"
+
+type %[2]s struct{}
+
+func %[3]s() (*%[2]s, error) { return nil, nil }
+
+func (*%[2]s) Run(context.Context) error { return nil }
+
+func (*%[2]s) Stop(context.Context) error { return nil }
+`, packageName, cfg.typeName, constructorName(cfg.typeName)))
+}
+
+// loader extracts the registrations of a type-checked package
+type loader struct {
+	pkg     *packages.Package
+	diag    *diagnostics
+	imports *importSet
+}
+
+// registrations returns the boot.Object chains of the package in source order
+func (l *loader) registrations() []*registration {
+	var regs []*registration
+	chained := make(map[*ast.CallExpr]bool)
+
+	for _, file := range l.pkg.Syntax {
+		ast.Inspect(file, func(n ast.Node) bool {
+			stmt, ok := n.(*ast.ExprStmt)
+			if !ok {
+				return true
+			}
+			call, ok := stmt.X.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			base, methods := l.chain(call)
+			if base == nil {
+				return true
+			}
+			chained[base] = true
+			if reg := l.registration(base, methods); reg != nil {
+				regs = append(regs, reg)
+			}
+			return true
+		})
+	}
+
+	// Any other use of the default container cannot be reproduced statically
+	for _, file := range l.pkg.Syntax {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			switch {
+			case l.isBootCall(call, "Object") && !chained[call]:
+				l.diag.errorf(call.Pos(), "unsupported registration: boot.Object must start a statement of its own")
+			case l.isBootCall(call, "Override"), l.isBootCall(call, "Decorate"):
+				l.diag.errorf(call.Pos(), "unsupported registration: %s is not supported by ginject-gen", l.callName(call))
+			}
+			return true
+		})
+	}
+	return regs
+}
+
+// chain splits a call chain into its boot.Object call and the builder calls
+// applied to it, in call order
+func (l *loader) chain(call *ast.CallExpr) (*ast.CallExpr, []*ast.CallExpr) {
+	var methods []*ast.CallExpr
+	for {
+		if l.isBootCall(call, "Object") {
+			for i, j := 0, len(methods)-1; i < j; i, j = i+1, j-1 {
+				methods[i], methods[j] = methods[j], methods[i]
+			}
+			return call, methods
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil, nil
+		}
+		inner, ok := selector.X.(*ast.CallExpr)
+		if !ok {
+			return nil, nil
+		}
+		methods = append(methods, call)
+		call = inner
+	}
+}
+
+// isBootCall reports whether call is boot.<name>(...)
+func (l *loader) isBootCall(call *ast.CallExpr, name string) bool {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != name {
+		return false
+	}
+	ident, ok := selector.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	pkgName, ok := l.pkg.TypesInfo.Uses[ident].(*types.PkgName)
+	return ok && pkgName.Imported().Path() == bootPath
+}
+
+func (l *loader) callName(call *ast.CallExpr) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, l.pkg.Fset, call.Fun)
+	return buf.String()
+}
+
+// registration interprets a boot.Object chain
+func (l *loader) registration(base *ast.CallExpr, methods []*ast.CallExpr) *registration {
+	if len(base.Args) != 1 {
+		l.diag.errorf(base.Pos(), "boot.Object expects one argument")
+		return nil
+	}
+	arg := base.Args[0]
+	reg := &registration{pos: base.Pos(), typ: l.pkg.TypesInfo.TypeOf(arg)}
+	if _, isInterface := reg.typ.Underlying().(*types.Interface); isInterface {
+		l.diag.errorf(arg.Pos(), "cannot generate wiring for a component of interface type %s", reg.typ)
+		return nil
+	}
+	if !l.checkExpression(arg) {
+		return nil
+	}
+	var buf bytes.Buffer
+	printer.Fprint(&buf, l.pkg.Fset, arg)
+	reg.expr = buf.String()
+	reg.exported = []types.Type{reg.typ}
+
+	for _, method := range methods {
+		name := method.Fun.(*ast.SelectorExpr).Sel.Name
+		switch name {
+		case "Name":
+			if value, ok := l.constantArg(method, constant.String); ok {
+				reg.name = constant.StringVal(value)
+				reg.nameSet = true
+			}
+		case "Priority":
+			if value, ok := l.constantArg(method, constant.Int); ok {
+				reg.priority, _ = constant.Int64Val(value)
+			}
+		case "Export":
+			l.export(reg, method)
+		case "Primary":
+			reg.primary = true
+		case "Fallback":
+			reg.fallback = true
+		case "DependsOn":
+			reg.dependsOn = append(reg.dependsOn, l.constantStrings(method)...)
+		case "Inject":
+			if len(method.Args) == 0 {
+				reg.inject = append(reg.inject, "Inject")
+			} else {
+				reg.inject = append(reg.inject, l.constantStrings(method)...)
+			}
+		case "Label", "Tags", "RestartPolicy":
+			// Metadata without effect on generated wiring
+		default:
+			l.diag.errorf(method.Pos(), "unsupported registration: %s is not supported by ginject-gen", name)
+		}
+	}
+	if reg.primary && reg.fallback {
+		l.diag.errorf(reg.pos, "component cannot be both Primary and Fallback")
+	}
+	if !reg.nameSet {
+		reg.name = defaultName(reg.typ)
+		if name, ok := l.namedConstant(reg.typ); ok {
+			reg.name = name
+			reg.nameSet = true
+		}
+	}
+	return reg
+}
+
+// namedConstant returns the name of a boot.Named component whose Name method
+// returns a string constant, as ObjectBuilder does at runtime
+func (l *loader) namedConstant(t types.Type) (string, bool) {
+	selection := types.NewMethodSet(t).Lookup(nil, "Name")
+	if selection == nil {
+		return "", false
+	}
+	method := selection.Obj().(*types.Func)
+	var name string
+	var found bool
+	packages.Visit([]*packages.Package{l.pkg}, nil, func(pkg *packages.Package) {
+		if found || pkg.Types != method.Pkg() {
+			return
+		}
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Name.Pos() != method.Pos() || fn.Body == nil || len(fn.Body.List) != 1 {
+					continue
+				}
+				ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+				if !ok || len(ret.Results) != 1 {
+					continue
+				}
+				if value := pkg.TypesInfo.Types[ret.Results[0]].Value; value != nil && value.Kind() == constant.String {
+					name, found = constant.StringVal(value), true
+				}
+			}
+		}
+	})
+	return name, found
+}
+
+// checkExpression makes sure a registration argument only refers to
+// package-level or imported identifiers, since it is copied into the
+// generated constructor
+func (l *loader) checkExpression(expr ast.Expr) bool {
+	ok := true
+	ast.Inspect(expr, func(n ast.Node) bool {
+		ident, isIdent := n.(*ast.Ident)
+		if !isIdent {
+			return true
+		}
+		obj := l.pkg.TypesInfo.Uses[ident]
+		if obj == nil {
+			return true
+		}
+		if pkgName, isPkg := obj.(*types.PkgName); isPkg {
+			if err := l.imports.addNamed(pkgName.Imported().Path(), pkgName.Name()); err != nil {
+				l.diag.errorf(ident.Pos(), "%v", err)
+				ok = false
+			}
+			return true
+		}
+		if obj.Pkg() == l.pkg.Types && obj.Parent() != nil && obj.Parent() != l.pkg.Types.Scope() {
+			l.diag.errorf(ident.Pos(), "registration argument refers to local %s; only package-level identifiers can be generated", ident.Name)
+			ok = false
+		}
+		return true
+	})
+	return ok
+}
+
+func (l *loader) constantArg(call *ast.CallExpr, kind constant.Kind) (constant.Value, bool) {
+	if len(call.Args) != 1 {
+		l.diag.errorf(call.Pos(), "%s expects one argument", l.callName(call))
+		return nil, false
+	}
+	value := l.pkg.TypesInfo.Types[call.Args[0]].Value
+	if value == nil || value.Kind() != kind {
+		l.diag.errorf(call.Args[0].Pos(), "%s argument must be a constant", l.callName(call))
+		return nil, false
+	}
+	return value, true
+}
+
+func (l *loader) constantStrings(call *ast.CallExpr) []string {
+	var values []string
+	for _, arg := range call.Args {
+		value := l.pkg.TypesInfo.Types[arg].Value
+		if value == nil || value.Kind() != constant.String {
+			l.diag.errorf(arg.Pos(), "%s arguments must be string constants", l.callName(call))
+			continue
+		}
+		values = append(values, constant.StringVal(value))
+	}
+	return values
+}
+
+// export mirrors ObjectBuilder.Export: a pointer to an interface exports the
+// interface, any other type is exported as is
+func (l *loader) export(reg *registration, call *ast.CallExpr) {
+	if len(call.Args) != 1 {
+		l.diag.errorf(call.Pos(), "Export expects one argument")
+		return
+	}
+	t := l.pkg.TypesInfo.TypeOf(call.Args[0])
+	if pointer, ok := t.(*types.Pointer); ok && types.IsInterface(pointer.Elem()) {
+		t = pointer.Elem()
+	}
+	if !types.AssignableTo(reg.typ, t) {
+		l.diag.errorf(call.Args[0].Pos(), "component type %s cannot be exported as %s", reg.typ, t)
+		return
+	}
+	reg.exported = append(reg.exported, t)
+}
+
+// defaultName mirrors the default component name of ObjectBuilder
+func defaultName(t types.Type) string {
+	if pointer, ok := t.(*types.Pointer); ok {
+		t = pointer.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return types.TypeString(t, nil)
+	}
+	pkgPath := named.Obj().Pkg().Path()
+	if named.Obj().Pkg().Name() == "main" {
+		// reflect reports "main" as the path of a command's package
+		pkgPath = "main"
+	}
+	return pkgPath + "." + named.Obj().Name()
+}
+
+// importSet tracks the imports of the generated file
+type importSet struct {
+	self   *types.Package
+	byPath map[string]string
+	byName map[string]string
+}
+
+func newImportSet(self *types.Package) *importSet {
+	s := &importSet{self: self, byPath: map[string]string{}, byName: map[string]string{}}
+	for _, path := range []string{"context", "errors", "fmt"} {
+		s.byPath[path] = path
+		s.byName[path] = path
+	}
+	return s
+}
+
+// addNamed imports path under the given name, as required by copied expressions
+func (s *importSet) addNamed(path, name string) error {
+	if existing, ok := s.byPath[path]; ok {
+		if existing != name {
+			return fmt.Errorf("package %s is imported as both %s and %s", path, existing, name)
+		}
+		return nil
+	}
+	if other, ok := s.byName[name]; ok {
+		return fmt.Errorf("import name %s refers to both %s and %s", name, other, path)
+	}
+	s.byPath[path] = name
+	s.byName[name] = path
+	return nil
+}
+
+// qualifier names packages in generated type expressions, importing them
+// under a free name when needed
+func (s *importSet) qualifier(pkg *types.Package) string {
+	if pkg == s.self {
+		return ""
+	}
+	if name, ok := s.byPath[pkg.Path()]; ok {
+		return name
+	}
+	name := pkg.Name()
+	for i := 2; s.byName[name] != ""; i++ {
+		name = fmt.Sprintf("%s%d", pkg.Name(), i)
+	}
+	s.byPath[pkg.Path()] = name
+	s.byName[name] = pkg.Path()
+	return name
+}
+
+// specs returns the import specs in path order
+func (s *importSet) specs() []string {
+	var specs []string
+	for path, name := range s.byPath {
+		base := path[strings.LastIndex(path, "/")+1:]
+		if name == base {
+			specs = append(specs, fmt.Sprintf("%q", path))
+		} else {
+			specs = append(specs, fmt.Sprintf("%s %q", name, path))
+		}
+	}
+	return specs
+}
@@ -0,0 +1,62 @@
+// Command ginject-gen generates reflection-free wiring for the components a
+// package registers with boot.Object.
+//
+// It reads the boot.Object registrations of the package in dir (default ".")
+// together with their Export, Name, Priority, Primary, Fallback, DependsOn
+// and Inject calls and the autowire tags of the registered types, then writes
+// a Go file declaring a type that constructs and wires the same graph with
+// plain assignments and runs Init, Start and Stop in the order Container.Run
+// uses:
+//
+//	//go:generate go run github.com/esclipez/ginject/cmd/ginject-gen
+//
+//	app, err := newGinjectApp()
+//	if err != nil { ... }
+//	if err := app.Run(ctx); err != nil { ... }
+//	defer app.Stop(ctx)
+//
+// Missing, ambiguous or mistyped dependencies are reported with the position
+// of the registration or field and make the command fail, so wiring errors
+// surface at build time instead of at startup.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	output := flag.String("o", "ginject_gen.go", "output file, relative to the package directory")
+	typeName := flag.String("type", "ginjectApp", "name of the generated type")
+	unexported := flag.Bool("unexported", false, "wire autowire-tagged unexported fields declared in the package")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: ginject-gen [flags] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+
+	cfg := config{dir: dir, output: *output, typeName: *typeName, unexported: *unexported}
+	if err := run(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run generates the wiring file for cfg
+func run(cfg config) error {
+	source, err := generate(cfg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(cfg.outputPath(), source, 0o644)
+}
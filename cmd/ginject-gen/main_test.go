@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testConfig(dir string) config {
+	return config{dir: filepath.Join("testdata", dir), output: "ginject_gen.go", typeName: "ginjectApp"}
+}
+
+func TestGenerateMatchesCheckedInOutput(t *testing.T) {
+	cfg := testConfig("app")
+	source, err := generate(cfg)
+	if err != nil {
+		t.Fatalf("expected generation to succeed, got %v", err)
+	}
+	expected, err := os.ReadFile(cfg.outputPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(source) != string(expected) {
+		t.Fatalf("generated wiring differs from %s, regenerate it with go generate:\n%s", cfg.outputPath(), source)
+	}
+}
+
+func TestGeneratedWiringFollowsContainerOrder(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the generated package")
+	}
+	cmd := exec.Command("go", "run", ".")
+	cmd.Dir = filepath.Join("testdata", "app")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("expected generated wiring to run, got %v:\n%s", err, output)
+	}
+
+	expected := []string{
+		"log store init",
+		"start store",
+		"start migrator",
+		"log server wired: true",
+		"start server",
+		"stop server",
+		"stop store",
+	}
+	if got := strings.Split(strings.TrimSpace(string(output)), "\n"); strings.Join(got, "|") != strings.Join(expected, "|") {
+		t.Fatalf("expected lifecycle %v, got %v", expected, got)
+	}
+}
+
+func TestGenerateReportsWiringErrors(t *testing.T) {
+	_, err := generate(testConfig("broken"))
+	if err == nil {
+		t.Fatal("expected wiring errors")
+	}
+
+	expected := []string{
+		"broken.go:30:31: boot.Object(&Service{}).Name argument must be a constant",
+		"broken.go:28:2: ambiguous components for type",
+		"broken.go:22:2: component 'github.com/esclipez/ginject/cmd/ginject-gen/testdata/broken.Service': failed to autowire required field Logger",
+		"broken.go:23:2: component 'github.com/esclipez/ginject/cmd/ginject-gen/testdata/broken.Service': failed to autowire required field Metrics",
+		"broken.go:24:2: component 'github.com/esclipez/ginject/cmd/ginject-gen/testdata/broken.Service': failed to autowire required field Cache: component 'cache' not found",
+	}
+	for _, message := range expected {
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("expected error %q, got:\n%v", message, err)
+		}
+	}
+}
+
+func TestGenerateWithoutPreviousOutput(t *testing.T) {
+	dir, err := os.MkdirTemp("testdata", "firstrun")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"app.go", "main.go"} {
+		source, err := os.ReadFile(filepath.Join("testdata", "app", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), source, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := config{dir: dir, output: "ginject_gen.go", typeName: "ginjectApp"}
+	if err := run(cfg); err != nil {
+		t.Fatalf("expected generation to succeed before the constructor exists, got %v", err)
+	}
+	if _, err := os.Stat(cfg.outputPath()); err != nil {
+		t.Fatalf("expected output file to be written, got %v", err)
+	}
+}
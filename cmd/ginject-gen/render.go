@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// generate loads the package of cfg and renders its wiring
+func generate(cfg config) ([]byte, error) {
+	pkg, err := loadPackage(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	diag := &diagnostics{fset: pkg.Fset}
+	imports := newImportSet(pkg.Types)
+	l := &loader{pkg: pkg, diag: diag, imports: imports}
+	g := newGraph(pkg.Types, diag, cfg.unexported, l.registrations())
+	g.resolve()
+	if err := diag.err(); err != nil {
+		return nil, err
+	}
+	return render(cfg, pkg.Types, imports, g)
+}
+
+// constructorName returns the name of the constructor of the generated type,
+// exported when the type is
+func constructorName(typeName string) string {
+	if unicode.IsUpper(rune(typeName[0])) {
+		return "New" + typeName
+	}
+	return "new" + string(unicode.ToUpper(rune(typeName[0]))) + typeName[1:]
+}
+
+// render writes the generated file
+func render(cfg config, pkg *types.Package, imports *importSet, g *graph) ([]byte, error) {
+	name := cfg.typeName
+	step := name + "Step"
+	constructor := constructorName(name)
+
+	// Type expressions register their imports, so render the body first
+	var body bytes.Buffer
+	w := func(format string, args ...interface{}) {
+		fmt.Fprintf(&body, format, args...)
+	}
+
+	w("// %s holds the components wired by ginject-gen without reflection\n", name)
+	w("type %s struct {\n", name)
+	for _, c := range g.components {
+		w("\t%s %s // %s\n", c.field(), types.TypeString(c.typ, imports.qualifier), c.name)
+	}
+	w("\tstarted []%s\n}\n\n", step)
+
+	w("type %s struct {\n\tname  string\n\tinit  func(context.Context) error\n", step)
+	w("\tstart func(context.Context) error\n\tstop  func(context.Context) error\n}\n\n")
+
+	w("// %s constructs the registered components in registration order and\n", constructor)
+	w("// wires their autowire fields and injection methods\n")
+	w("func %s() (*%s, error) {\n\ta := &%s{}\n", constructor, name, name)
+	for _, c := range g.components {
+		w("\ta.%s = %s\n", c.field(), c.expr)
+	}
+	for _, c := range g.components {
+		if len(c.assignments) == 0 && len(c.calls) == 0 {
+			continue
+		}
+		w("\n\t// %s\n", c.name)
+		for _, assignment := range c.assignments {
+			if assignment.source == nil {
+				guards := append(append([]string{}, assignment.guards...), assignment.target+" == nil")
+				w("\tif %s {\n\t\t%s = new(%s)\n\t}\n", strings.Join(guards, " && "), assignment.target,
+					types.TypeString(assignment.allocate, imports.qualifier))
+				continue
+			}
+			line := fmt.Sprintf("%s = a.%s", assignment.target, assignment.source.field())
+			if len(assignment.guards) == 0 {
+				w("\t%s\n", line)
+				continue
+			}
+			w("\tif %s {\n\t\t%s\n\t}\n", strings.Join(assignment.guards, " && "), line)
+		}
+		for _, call := range c.calls {
+			args := make([]string, len(call.args))
+			for i, arg := range call.args {
+				args[i] = "a." + arg.field()
+			}
+			invocation := fmt.Sprintf("a.%s.%s(%s)", c.field(), call.method, strings.Join(args, ", "))
+			if !call.returnErr {
+				w("\t%s\n", invocation)
+				continue
+			}
+			w("\tif err := %s; err != nil {\n", invocation)
+			w("\t\treturn nil, fmt.Errorf(\"failed to inject dependencies for '%%s': injection method %s failed: %%w\", %q, err)\n\t}\n",
+				call.method, c.name)
+		}
+	}
+	w("\treturn a, nil\n}\n\n")
+
+	w("// steps returns the lifecycle hooks of the components in start order\n")
+	w("func (a *%s) steps() []%s {\n\treturn []%s{\n", name, step, step)
+	for _, c := range g.ordered() {
+		hooks := []string{fmt.Sprintf("name: %q", c.name)}
+		if c.init {
+			hooks = append(hooks, fmt.Sprintf("init: a.%s.Init", c.field()))
+		}
+		if c.start {
+			hooks = append(hooks, fmt.Sprintf("start: a.%s.Start", c.field()))
+		}
+		if c.stop {
+			hooks = append(hooks, fmt.Sprintf("stop: a.%s.Stop", c.field()))
+		}
+		w("\t\t{%s},\n", strings.Join(hooks, ", "))
+	}
+	w("\t}\n}\n\n")
+
+	w(`// Run calls Init and then Start on the components in the order
+// Container.Run uses. If a component fails to start, the components started
+// before it are stopped again in reverse order.
+func (a *%[1]s) Run(ctx context.Context) error {
+	steps := a.steps()
+	for _, step := range steps {
+		if step.init == nil {
+			continue
+		}
+		if err := step.init(ctx); err != nil {
+			return fmt.Errorf("initialization failed for '%%s': %%w", step.name, err)
+		}
+	}
+	for _, step := range steps {
+		if step.start != nil {
+			if err := step.start(ctx); err != nil {
+				err = fmt.Errorf("startup failed for '%%s': %%w", step.name, err)
+				if stopErr := a.Stop(ctx); stopErr != nil {
+					return errors.Join(err, stopErr)
+				}
+				return err
+			}
+		}
+		a.started = append(a.started, step)
+	}
+	return nil
+}
+
+// Stop calls Stop on the started components in reverse start order and
+// returns the last error
+func (a *%[1]s) Stop(ctx context.Context) error {
+	var lastErr error
+	for i := len(a.started) - 1; i >= 0; i-- {
+		step := a.started[i]
+		if step.stop == nil {
+			continue
+		}
+		if err := step.stop(ctx); err != nil {
+			lastErr = fmt.Errorf("shutdown failed for '%%s': %%w", step.name, err)
+		}
+	}
+	a.started = nil
+	return lastErr
+}
+`, name)
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by ginject-gen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg.Name())
+	specs := imports.specs()
+	sort.Slice(specs, func(i, j int) bool {
+		return importPath(specs[i]) < importPath(specs[j])
+	})
+	for _, spec := range specs {
+		if !strings.Contains(importPath(spec), ".") {
+			fmt.Fprintf(&out, "\t%s\n", spec)
+		}
+	}
+	out.WriteString("\n")
+	for _, spec := range specs {
+		if strings.Contains(importPath(spec), ".") {
+			fmt.Fprintf(&out, "\t%s\n", spec)
+		}
+	}
+	out.WriteString(")\n\n")
+	out.Write(body.Bytes())
+
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return source, nil
+}
+
+// importPath returns the quoted path of an import spec
+func importPath(spec string) string {
+	return spec[strings.Index(spec, `"`):]
+}
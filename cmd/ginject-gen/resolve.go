@@ -0,0 +1,447 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// component is a registration with its resolved wiring
+type component struct {
+	*registration
+	index       int
+	assignments []assignment
+	calls       []methodCall
+	init        bool
+	start       bool
+	stop        bool
+}
+
+// field returns the name of the generated struct field holding the component
+func (c *component) field() string {
+	return fmt.Sprintf("c%d", c.index)
+}
+
+// assignment sets a field of a component, guarded by nil checks of the
+// pointers leading to it. A nil source allocates the field with new(allocate)
+// when it is nil.
+type assignment struct {
+	guards   []string
+	target   string
+	source   *component
+	allocate types.Type
+}
+
+// methodCall is an injection method invoked with resolved arguments
+type methodCall struct {
+	method    string
+	args      []*component
+	returnErr bool
+}
+
+// graph holds the components of a package and the interfaces of boot
+type graph struct {
+	pkg          *types.Package
+	diag         *diagnostics
+	unexported   bool
+	components   []*component
+	byName       map[string]*component
+	initializer  *types.Interface
+	starter      *types.Interface
+	stopper      *types.Interface
+	unsupported  map[string]*types.Interface
+	namedIface   *types.Interface
+	resolvedType map[types.Type]*component
+}
+
+func newGraph(pkg *types.Package, diag *diagnostics, unexported bool, regs []*registration) *graph {
+	g := &graph{
+		pkg:         pkg,
+		diag:        diag,
+		unexported:  unexported,
+		byName:      map[string]*component{},
+		unsupported: map[string]*types.Interface{},
+	}
+	for _, imported := range pkg.Imports() {
+		if imported.Path() != bootPath {
+			continue
+		}
+		lookup := func(name string) *types.Interface {
+			return imported.Scope().Lookup(name).Type().Underlying().(*types.Interface)
+		}
+		g.initializer = lookup("Initializable")
+		g.starter = lookup("Startable")
+		g.stopper = lookup("Stoppable")
+		g.namedIface = lookup("Named")
+		g.unsupported["boot.Runnable"] = lookup("Runnable")
+		g.unsupported["boot.ComponentPostProcessor"] = lookup("ComponentPostProcessor")
+	}
+
+	for i, reg := range regs {
+		c := &component{registration: reg, index: i}
+		if existing, ok := g.byName[reg.name]; ok {
+			diag.errorf(reg.pos, "component with name '%s' already registered at %s", reg.name, diag.fset.Position(existing.pos))
+			continue
+		}
+		g.byName[reg.name] = c
+		g.components = append(g.components, c)
+	}
+	return g
+}
+
+// resolve validates the graph and computes the wiring of every component
+func (g *graph) resolve() {
+	for _, c := range g.components {
+		if g.namedIface != nil && !c.nameSet && types.Implements(c.typ, g.namedIface) {
+			g.diag.errorf(c.pos, "component %s implements boot.Named without returning a constant; set its name with Name for ginject-gen", c.typ)
+		}
+		for name, iface := range g.unsupported {
+			if types.Implements(c.typ, iface) {
+				g.diag.errorf(c.pos, "component '%s' implements %s, which ginject-gen does not support", c.name, name)
+			}
+		}
+		c.init = g.initializer != nil && types.Implements(c.typ, g.initializer)
+		c.start = g.starter != nil && types.Implements(c.typ, g.starter)
+		c.stop = g.stopper != nil && types.Implements(c.typ, g.stopper)
+	}
+
+	g.validateTypes()
+	g.validateDependsOn()
+
+	for _, c := range g.components {
+		if st, ok := structOf(c.typ); ok {
+			g.wireStruct(c, st, "a."+c.field(), "", nil, map[types.Type]bool{})
+		}
+		g.wireMethods(c)
+	}
+}
+
+// validateTypes mirrors validateTypeRegistrations: every exported type needs
+// a single candidate, ignoring fallbacks, or exactly one primary
+func (g *graph) validateTypes() {
+	g.resolvedType = map[types.Type]*component{}
+	var order []types.Type
+	groups := map[types.Type][]*component{}
+	for _, c := range g.components {
+		for _, exported := range c.exported {
+			key := typeKey(exported, order)
+			if key == nil {
+				key = exported
+				order = append(order, key)
+			}
+			groups[key] = append(groups[key], c)
+		}
+	}
+
+	for _, t := range order {
+		candidates := preferNonFallback(groups[t])
+		if len(candidates) == 1 {
+			g.resolvedType[t] = candidates[0]
+			continue
+		}
+		var primaries []*component
+		for _, c := range candidates {
+			if c.primary {
+				primaries = append(primaries, c)
+			}
+		}
+		switch len(primaries) {
+		case 1:
+			g.resolvedType[t] = primaries[0]
+		case 0:
+			g.diag.errorf(candidates[0].pos, "ambiguous components for type '%s': %v (mark one as Primary())", t, names(candidates))
+		default:
+			g.diag.errorf(primaries[0].pos, "multiple primary components for type '%s': %v", t, names(primaries))
+		}
+	}
+}
+
+// typeKey returns the type identical to t among seen, or nil
+func typeKey(t types.Type, seen []types.Type) types.Type {
+	for _, existing := range seen {
+		if types.Identical(existing, t) {
+			return existing
+		}
+	}
+	return nil
+}
+
+// byType returns the component resolved for t, if any
+func (g *graph) byType(t types.Type) *component {
+	for exported, c := range g.resolvedType {
+		if types.Identical(exported, t) {
+			return c
+		}
+	}
+	return nil
+}
+
+func preferNonFallback(components []*component) []*component {
+	var preferred []*component
+	for _, c := range components {
+		if !c.fallback {
+			preferred = append(preferred, c)
+		}
+	}
+	if len(preferred) == 0 {
+		return components
+	}
+	return preferred
+}
+
+func names(components []*component) []string {
+	result := make([]string, len(components))
+	for i, c := range components {
+		result[i] = c.name
+	}
+	return result
+}
+
+// validateDependsOn mirrors the DependsOn checks of Container.Run
+func (g *graph) validateDependsOn() {
+	for _, c := range g.components {
+		for _, name := range c.dependsOn {
+			if name == c.name {
+				g.diag.errorf(c.pos, "component '%s' depends on itself", c.name)
+			} else if g.byName[name] == nil {
+				g.diag.errorf(c.pos, "component '%s' depends on unknown component '%s'", c.name, name)
+			}
+		}
+	}
+
+	marks := map[*component]int{}
+	var path []string
+	var visit func(c *component) bool
+	visit = func(c *component) bool {
+		if marks[c] == 2 {
+			return true
+		}
+		if marks[c] == 1 {
+			for i, name := range path {
+				if name == c.name {
+					cycle := append(path[i:len(path):len(path)], c.name)
+					g.diag.errorf(c.pos, "dependency cycle: %s", strings.Join(cycle, " -> "))
+				}
+			}
+			return false
+		}
+		marks[c] = 1
+		path = append(path, c.name)
+		for _, name := range c.dependsOn {
+			if dependency := g.byName[name]; dependency != nil && dependency != c && !visit(dependency) {
+				return false
+			}
+		}
+		path = path[:len(path)-1]
+		marks[c] = 2
+		return true
+	}
+	for _, c := range g.components {
+		if !visit(c) {
+			return
+		}
+	}
+}
+
+// ordered returns the components in start order: priority descending in
+// registration order, each moved after the components it depends on
+func (g *graph) ordered() []*component {
+	components := append([]*component{}, g.components...)
+	sort.SliceStable(components, func(i, j int) bool {
+		return components[i].priority > components[j].priority
+	})
+
+	remaining := map[string]bool{}
+	for _, c := range components {
+		remaining[c.name] = true
+	}
+	var ordered []*component
+	for len(components) > 0 {
+		next := 0
+		for i, c := range components {
+			ready := true
+			for _, name := range c.dependsOn {
+				ready = ready && !remaining[name]
+			}
+			if ready {
+				next = i
+				break
+			}
+		}
+		ordered = append(ordered, components[next])
+		delete(remaining, components[next].name)
+		components = append(components[:next:next], components[next+1:]...)
+	}
+	return ordered
+}
+
+// structOf returns the struct behind a struct or struct pointer type
+func structOf(t types.Type) (*types.Struct, bool) {
+	if pointer, ok := t.(*types.Pointer); ok {
+		t = pointer.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	return st, ok
+}
+
+// wireStruct mirrors field injection for the struct accessed through access.
+// Untagged nested structs are scanned, and untagged struct pointers are
+// scanned behind a nil check.
+func (g *graph) wireStruct(c *component, st *types.Struct, access, path string, guards []string, seen map[types.Type]bool) {
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		tag, tagged := reflect.StructTag(st.Tag(i)).Lookup("autowire")
+		target := access + "." + field.Name()
+
+		if !tagged {
+			if !field.Exported() && !g.canSetUnexported(field) {
+				continue
+			}
+			nested := field.Type()
+			pointer, isPointer := nested.(*types.Pointer)
+			if isPointer {
+				nested = pointer.Elem()
+			}
+			nestedStruct, ok := nested.Underlying().(*types.Struct)
+			if !ok || seen[nested] {
+				continue
+			}
+			seen[nested] = true
+			nestedGuards := guards
+			if isPointer {
+				nestedGuards = append(append([]string{}, guards...), target+" != nil")
+			}
+			g.wireStruct(c, nestedStruct, target, path+field.Name()+".", nestedGuards, seen)
+			delete(seen, nested)
+			continue
+		}
+
+		if !field.Exported() && !g.canSetUnexported(field) {
+			g.diag.errorf(field.Pos(), "cannot autowire unexported field %s of '%s': export it or pass -unexported for fields of this package",
+				path+field.Name(), c.name)
+			continue
+		}
+		if tag == "new" {
+			g.wireNew(c, field, target, path+field.Name(), guards, seen)
+			continue
+		}
+		source, ok := g.resolveTag(c, field, tag, path+field.Name())
+		if ok && source != nil {
+			c.assignments = append(c.assignments, assignment{guards: guards, target: target, source: source})
+		}
+	}
+}
+
+// wireNew mirrors autowire:"new": a nil struct pointer is allocated, then the
+// struct is wired like a nested struct
+func (g *graph) wireNew(c *component, field *types.Var, target, path string, guards []string, seen map[types.Type]bool) {
+	nested := field.Type()
+	pointer, isPointer := nested.(*types.Pointer)
+	if isPointer {
+		nested = pointer.Elem()
+	}
+	st, ok := nested.Underlying().(*types.Struct)
+	if !ok {
+		g.diag.errorf(field.Pos(), "cannot allocate field %s of '%s': autowire:\"new\" requires a struct or struct pointer, got %s",
+			path, c.name, field.Type())
+		return
+	}
+	if seen[nested] {
+		g.diag.errorf(field.Pos(), "cannot allocate field %s of '%s': %s is already being allocated on this path", path, c.name, nested)
+		return
+	}
+	if isPointer {
+		c.assignments = append(c.assignments, assignment{guards: guards, target: target, allocate: nested})
+	}
+	seen[nested] = true
+	g.wireStruct(c, st, target, path+".", guards, seen)
+	delete(seen, nested)
+}
+
+func (g *graph) canSetUnexported(field *types.Var) bool {
+	return g.unexported && field.Pkg() == g.pkg
+}
+
+// resolveTag mirrors resolveDependencyUnsafe for a tagged field. A nil
+// source with ok set leaves an optional field untouched.
+func (g *graph) resolveTag(c *component, field *types.Var, tag, path string) (*component, bool) {
+	fail := func(pos token.Pos, format string, args ...interface{}) (*component, bool) {
+		g.diag.errorf(pos, "component '%s': failed to autowire required field %s: %s", c.name, path, fmt.Sprintf(format, args...))
+		return nil, false
+	}
+
+	if strings.HasPrefix(tag, "@") {
+		return fail(field.Pos(), "qualifier %q is not supported by ginject-gen", tag)
+	}
+
+	optional := tag == "optional" || tag == "?" || strings.HasSuffix(tag, ",optional")
+	name := strings.TrimSuffix(tag, ",optional")
+	if tag == "optional" || tag == "?" || name == "required" {
+		name = ""
+	}
+
+	if name == "" {
+		if source := g.byType(field.Type()); source != nil {
+			return source, true
+		}
+		if optional {
+			return nil, true
+		}
+		return fail(field.Pos(), "no component of type '%s' found", field.Type())
+	}
+
+	source := g.byName[name]
+	if source == nil {
+		if optional {
+			return nil, true
+		}
+		return fail(field.Pos(), "component '%s' not found", name)
+	}
+	if !types.AssignableTo(source.typ, field.Type()) {
+		if optional {
+			return nil, true
+		}
+		return fail(field.Pos(), "component '%s' (type %s) is not assignable to field type %s", name, source.typ, field.Type())
+	}
+	return source, true
+}
+
+// wireMethods mirrors method injection: the methods registered with Inject
+// are called in order
+func (g *graph) wireMethods(c *component) {
+	methods := types.NewMethodSet(c.typ)
+	for _, name := range c.inject {
+		selection := methods.Lookup(nil, name)
+		if selection == nil {
+			g.diag.errorf(c.pos, "injection method %s not found on %s", name, c.typ)
+			continue
+		}
+		signature := selection.Type().(*types.Signature)
+		if signature.Variadic() {
+			g.diag.errorf(c.pos, "injection method %s must not be variadic", name)
+			continue
+		}
+		results := signature.Results()
+		returnErr := results.Len() == 1 && types.Identical(results.At(0).Type(), types.Universe.Lookup("error").Type())
+		if results.Len() > 1 || (results.Len() == 1 && !returnErr) {
+			g.diag.errorf(c.pos, "injection method %s must return nothing or an error", name)
+			continue
+		}
+
+		call := methodCall{method: name, returnErr: returnErr}
+		params := signature.Params()
+		for i := 0; i < params.Len(); i++ {
+			source := g.byType(params.At(i).Type())
+			if source == nil {
+				g.diag.errorf(c.pos, "component '%s': failed to resolve parameter %d of injection method %s: no component of type '%s' found",
+					c.name, i, name, params.At(i).Type())
+				continue
+			}
+			call.args = append(call.args, source)
+		}
+		c.calls = append(c.calls, call)
+	}
+}
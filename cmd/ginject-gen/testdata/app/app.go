@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/esclipez/ginject/boot"
+)
+
+var events []string
+
+func record(format string, args ...interface{}) {
+	events = append(events, fmt.Sprintf(format, args...))
+}
+
+type Logger interface {
+	Log(message string)
+}
+
+type consoleLogger struct{}
+
+func (l *consoleLogger) Log(message string) {
+	record("log %s", message)
+}
+
+type nopLogger struct{}
+
+func (l *nopLogger) Log(string) {}
+
+type Store struct {
+	Logger Logger `autowire:""`
+}
+
+func (s *Store) Init(context.Context) error {
+	s.Logger.Log("store init")
+	return nil
+}
+
+func (s *Store) Start(context.Context) error {
+	record("start store")
+	return nil
+}
+
+func (s *Store) Stop(context.Context) error {
+	record("stop store")
+	return nil
+}
+
+type Migrator struct{}
+
+func (m *Migrator) Name() string {
+	return "migrator"
+}
+
+func (m *Migrator) Start(context.Context) error {
+	record("start migrator")
+	return nil
+}
+
+type Handlers struct {
+	Store *Store `autowire:"store"`
+}
+
+type Server struct {
+	Handlers
+	Audit  Logger    `autowire:"audit,optional"`
+	Extra  *Handlers `autowire:"new"`
+	logger Logger
+}
+
+func (s *Server) Inject(logger Logger) {
+	s.logger = logger
+}
+
+func (s *Server) Start(context.Context) error {
+	s.logger.Log(fmt.Sprintf("server wired: %t", s.Handlers.Store != nil && s.Extra.Store != nil && s.Audit == nil))
+	record("start server")
+	return nil
+}
+
+func (s *Server) Stop(context.Context) error {
+	record("stop server")
+	return nil
+}
+
+func init() {
+	boot.Object(&Server{}).Name("server").Priority(10).DependsOn("migrator").Inject()
+	boot.Object(&Store{}).Name("store").Priority(5)
+	boot.Object(&Migrator{})
+	boot.Object(&nopLogger{}).Export((*Logger)(nil)).Fallback()
+	boot.Object(&consoleLogger{}).Export((*Logger)(nil))
+}
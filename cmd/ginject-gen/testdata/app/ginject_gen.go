@@ -0,0 +1,105 @@
+// Code generated by ginject-gen. DO NOT EDIT.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ginjectApp holds the components wired by ginject-gen without reflection
+type ginjectApp struct {
+	c0      *Server        // server
+	c1      *Store         // store
+	c2      *Migrator      // migrator
+	c3      *nopLogger     // main.nopLogger
+	c4      *consoleLogger // main.consoleLogger
+	started []ginjectAppStep
+}
+
+type ginjectAppStep struct {
+	name  string
+	init  func(context.Context) error
+	start func(context.Context) error
+	stop  func(context.Context) error
+}
+
+// newGinjectApp constructs the registered components in registration order and
+// wires their autowire fields and injection methods
+func newGinjectApp() (*ginjectApp, error) {
+	a := &ginjectApp{}
+	a.c0 = &Server{}
+	a.c1 = &Store{}
+	a.c2 = &Migrator{}
+	a.c3 = &nopLogger{}
+	a.c4 = &consoleLogger{}
+
+	// server
+	a.c0.Handlers.Store = a.c1
+	if a.c0.Extra == nil {
+		a.c0.Extra = new(Handlers)
+	}
+	a.c0.Extra.Store = a.c1
+	a.c0.Inject(a.c4)
+
+	// store
+	a.c1.Logger = a.c4
+	return a, nil
+}
+
+// steps returns the lifecycle hooks of the components in start order
+func (a *ginjectApp) steps() []ginjectAppStep {
+	return []ginjectAppStep{
+		{name: "store", init: a.c1.Init, start: a.c1.Start, stop: a.c1.Stop},
+		{name: "migrator", start: a.c2.Start},
+		{name: "server", start: a.c0.Start, stop: a.c0.Stop},
+		{name: "main.nopLogger"},
+		{name: "main.consoleLogger"},
+	}
+}
+
+// Run calls Init and then Start on the components in the order
+// Container.Run uses. If a component fails to start, the components started
+// before it are stopped again in reverse order.
+func (a *ginjectApp) Run(ctx context.Context) error {
+	steps := a.steps()
+	for _, step := range steps {
+		if step.init == nil {
+			continue
+		}
+		if err := step.init(ctx); err != nil {
+			return fmt.Errorf("initialization failed for '%s': %w", step.name, err)
+		}
+	}
+	for _, step := range steps {
+		if step.start != nil {
+			if err := step.start(ctx); err != nil {
+				err = fmt.Errorf("startup failed for '%s': %w", step.name, err)
+				if stopErr := a.Stop(ctx); stopErr != nil {
+					return errors.Join(err, stopErr)
+				}
+				return err
+			}
+		}
+		a.started = append(a.started, step)
+	}
+	return nil
+}
+
+// Stop calls Stop on the started components in reverse start order and
+// returns the last error
+func (a *ginjectApp) Stop(ctx context.Context) error {
+	var lastErr error
+	for i := len(a.started) - 1; i >= 0; i-- {
+		step := a.started[i]
+		if step.stop == nil {
+			continue
+		}
+		if err := step.stop(ctx); err != nil {
+			lastErr = fmt.Errorf("shutdown failed for '%s': %w", step.name, err)
+		}
+	}
+	a.started = nil
+	return lastErr
+}
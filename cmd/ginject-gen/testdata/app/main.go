@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+//go:generate go run github.com/esclipez/ginject/cmd/ginject-gen
+
+func main() {
+	ctx := context.Background()
+	app, err := newGinjectApp()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if err := app.Run(ctx); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if err := app.Stop(ctx); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Println(strings.Join(events, "\n"))
+}
@@ -0,0 +1,31 @@
+package broken
+
+import "github.com/esclipez/ginject/boot"
+
+type Logger interface {
+	Log(message string)
+}
+
+type fileLogger struct{}
+
+func (l *fileLogger) Log(string) {}
+
+type consoleLogger struct{}
+
+func (l *consoleLogger) Log(string) {}
+
+type Metrics interface {
+	Count(name string)
+}
+
+type Service struct {
+	Logger  Logger  `autowire:""`
+	Metrics Metrics `autowire:""`
+	Cache   Logger  `autowire:"cache"`
+}
+
+func register(name string) {
+	boot.Object(&fileLogger{}).Export((*Logger)(nil))
+	boot.Object(&consoleLogger{}).Export((*Logger)(nil))
+	boot.Object(&Service{}).Name(name)
+}
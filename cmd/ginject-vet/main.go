@@ -0,0 +1,18 @@
+// Command ginject-vet runs the autowire analyzer as a go vet tool:
+//
+//	go install github.com/esclipez/ginject/cmd/ginject-vet
+//	go vet -vettool=$(which ginject-vet) ./...
+//
+// Pass -autowire.unexported when the containers are created with
+// boot.WithUnexportedFieldInjection().
+package main
+
+import (
+	"github.com/esclipez/ginject/analysis/autowire"
+
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+func main() {
+	unitchecker.Main(autowire.Analyzer)
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/esclipez/ginject/boot"
+)
+
+func runValidate(cfg *config, args []string, stdout io.Writer) error {
+	pkg, err := packageArg(args, 0)
+	if err != nil {
+		return err
+	}
+	report, err := loadReport(cfg, pkg)
+	if err != nil {
+		return err
+	}
+	if err := invalid(report); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "ok: %d components wired\n", len(report.Components))
+	return nil
+}
+
+func runInspect(cfg *config, args []string, stdout io.Writer) error {
+	pkg, err := packageArg(args, 0)
+	if err != nil {
+		return err
+	}
+	report, err := loadReport(cfg, pkg)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	return invalid(report)
+}
+
+func runGraph(cfg *config, args []string, stdout io.Writer) error {
+	if cfg.format != "text" && cfg.format != "dot" {
+		return fmt.Errorf("unknown format %q", cfg.format)
+	}
+	pkg, err := packageArg(args, 0)
+	if err != nil {
+		return err
+	}
+	report, err := loadReport(cfg, pkg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.format == "dot" {
+		fmt.Fprintln(stdout, "digraph ginject {")
+	}
+	for _, component := range order(report) {
+		edges := 0
+		for _, dependency := range component.Dependencies {
+			for _, target := range dependency.Components {
+				writeEdge(stdout, cfg.format, component.Name, target, dependency.Field)
+				edges++
+			}
+		}
+		for _, target := range component.DependsOn {
+			writeEdge(stdout, cfg.format, component.Name, target, "")
+			edges++
+		}
+		if edges > 0 {
+			continue
+		}
+		if cfg.format == "dot" {
+			fmt.Fprintf(stdout, "  %s;\n", strconv.Quote(component.Name))
+		} else {
+			fmt.Fprintln(stdout, component.Name)
+		}
+	}
+	if cfg.format == "dot" {
+		fmt.Fprintln(stdout, "}")
+	}
+	return invalid(report)
+}
+
+// writeEdge writes an injection edge for field, or a DependsOn edge when
+// field is empty
+func writeEdge(w io.Writer, format, from, to, field string) {
+	if format == "dot" {
+		attributes := "style=dashed"
+		if field != "" {
+			attributes = "label=" + strconv.Quote(field)
+		}
+		fmt.Fprintf(w, "  %s -> %s [%s];\n", strconv.Quote(from), strconv.Quote(to), attributes)
+		return
+	}
+	if field == "" {
+		field = "DependsOn"
+	}
+	fmt.Fprintf(w, "%s -> %s (%s)\n", from, to, field)
+}
+
+func runExplain(cfg *config, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	name := args[0]
+	pkg, err := packageArg(args, 1)
+	if err != nil {
+		return err
+	}
+	report, err := loadReport(cfg, pkg)
+	if err != nil {
+		return err
+	}
+
+	var component *boot.ComponentReport
+	for i := range report.Components {
+		if report.Components[i].Name == name {
+			component = &report.Components[i]
+		}
+	}
+	if component == nil {
+		if err := invalid(report); err != nil {
+			return fmt.Errorf("component '%s' not found; %w", name, err)
+		}
+		return fmt.Errorf("component '%s' not found", name)
+	}
+
+	fmt.Fprintf(stdout, "%s (%s)\n", component.Name, component.Type)
+	fmt.Fprintf(stdout, "  exports:    %s\n", strings.Join(component.ExportedTypes, ", "))
+	fmt.Fprintf(stdout, "  priority:   %d\n", component.Priority)
+	for i, started := range report.StartOrder {
+		if started == name {
+			fmt.Fprintf(stdout, "  starts:     %d of %d\n", i+1, len(report.StartOrder))
+		}
+	}
+	if component.Primary {
+		fmt.Fprintln(stdout, "  primary:    true")
+	}
+	if component.Fallback {
+		fmt.Fprintln(stdout, "  fallback:   true")
+	}
+	if len(component.Labels) > 0 {
+		fmt.Fprintf(stdout, "  labels:     %s\n", formatLabels(component.Labels))
+	}
+	if len(component.DependsOn) > 0 {
+		fmt.Fprintf(stdout, "  depends on: %s\n", strings.Join(component.DependsOn, ", "))
+	}
+
+	if len(component.Dependencies) > 0 {
+		fmt.Fprintln(stdout, "  fields:")
+		for _, dependency := range component.Dependencies {
+			target := strings.Join(dependency.Components, ", ")
+			if target == "" {
+				target = "(not injected)"
+			}
+			fmt.Fprintf(stdout, "    %s %s `autowire:%q` -> %s\n", dependency.Field, dependency.Type, dependency.Qualifier, target)
+		}
+	}
+
+	var usedBy []string
+	for _, other := range report.Components {
+		for _, dependency := range other.Dependencies {
+			for _, target := range dependency.Components {
+				if target == name {
+					usedBy = append(usedBy, other.Name+" ("+dependency.Field+")")
+				}
+			}
+		}
+		for _, target := range other.DependsOn {
+			if target == name {
+				usedBy = append(usedBy, other.Name+" (DependsOn)")
+			}
+		}
+	}
+	if len(usedBy) > 0 {
+		fmt.Fprintln(stdout, "  used by:")
+		for _, user := range usedBy {
+			fmt.Fprintf(stdout, "    %s\n", user)
+		}
+	}
+	return invalid(report)
+}
+
+// formatLabels returns the labels as sorted key=value pairs, tags without a value
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		if value == "" {
+			pairs = append(pairs, key)
+			continue
+		}
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,94 @@
+// Command ginject inspects the wiring of an application built with ginject.
+//
+// It builds the main package, runs it with GINJECT_MODE=inspect so that
+// boot.Run and boot.RunApplication report the registered components and
+// their wiring instead of starting them, and prints the result:
+//
+//	ginject validate ./cmd/server          exit status 1 when the wiring is invalid
+//	ginject inspect ./cmd/server           the full report as JSON
+//	ginject graph -format dot ./cmd/server the dependency graph
+//	ginject explain database ./cmd/server  a component, its dependencies and dependents
+//
+// The package defaults to ".". Code in main that runs before boot.Run is
+// executed as usual, so it must not block or require arguments.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// command is a ginject subcommand
+type command struct {
+	name  string
+	usage string
+	run   func(cfg *config, args []string, stdout io.Writer) error
+}
+
+// config holds the flags shared by all subcommands
+type config struct {
+	timeout time.Duration
+	format  string
+}
+
+var commands = []command{
+	{name: "validate", usage: "[package]", run: runValidate},
+	{name: "inspect", usage: "[package]", run: runInspect},
+	{name: "graph", usage: "[-format text|dot] [package]", run: runGraph},
+	{name: "explain", usage: "<component> [package]", run: runExplain},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the subcommand in args and returns the exit status
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		flags := flag.NewFlagSet("ginject "+cmd.name, flag.ContinueOnError)
+		flags.SetOutput(stderr)
+		cfg := &config{}
+		flags.DurationVar(&cfg.timeout, "timeout", time.Minute, "how long the application may take to report its wiring")
+		if cmd.name == "graph" {
+			flags.StringVar(&cfg.format, "format", "text", "output format: text or dot")
+		}
+		flags.Usage = func() {
+			fmt.Fprintf(stderr, "usage: ginject %s [-timeout d] %s\n", cmd.name, cmd.usage)
+			flags.PrintDefaults()
+		}
+		if err := flags.Parse(args[1:]); err != nil {
+			return 2
+		}
+
+		if err := cmd.run(cfg, flags.Args(), stdout); err != nil {
+			if err == errUsage {
+				flags.Usage()
+				return 2
+			}
+			fmt.Fprintf(stderr, "ginject %s: %v\n", cmd.name, err)
+			return 1
+		}
+		return 0
+	}
+	usage(stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: ginject <command> [flags] [arguments]")
+	fmt.Fprintln(w, "\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-9s %s\n", cmd.name, cmd.usage)
+	}
+}
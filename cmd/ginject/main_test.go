@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/esclipez/ginject/boot"
+)
+
+func runCommand(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("builds the inspected application")
+	}
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestValidateReportsValidWiring(t *testing.T) {
+	code, stdout, stderr := runCommand(t, "validate", "./testdata/app")
+	if code != 0 {
+		t.Fatalf("expected exit status 0, got %d: %s", code, stderr)
+	}
+	if stdout != "ok: 3 components wired\n" {
+		t.Fatalf("expected summary, got %q", stdout)
+	}
+}
+
+func TestValidateFailsOnInvalidWiring(t *testing.T) {
+	code, _, stderr := runCommand(t, "validate", "./testdata/broken")
+	if code != 1 {
+		t.Fatalf("expected exit status 1, got %d", code)
+	}
+	if !strings.Contains(stderr, "invalid wiring: dependency injection failed") || !strings.Contains(stderr, "component 'store' not found") {
+		t.Fatalf("expected injection error, got %q", stderr)
+	}
+}
+
+func TestInspectPrintsReportWithoutStarting(t *testing.T) {
+	code, stdout, stderr := runCommand(t, "inspect", "./testdata/app")
+	if code != 0 {
+		t.Fatalf("expected exit status 0, got %d: %s", code, stderr)
+	}
+	if strings.Contains(stdout, "initialized") || strings.Contains(stdout, "started") {
+		t.Fatalf("expected no lifecycle calls, got %q", stdout)
+	}
+
+	var report boot.InspectReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("expected JSON report, got %v", err)
+	}
+	if got := strings.Join(report.StartOrder, ","); got != "store,migrator,server" {
+		t.Fatalf("expected start order store,migrator,server, got %s", got)
+	}
+}
+
+func TestGraphPrintsEdges(t *testing.T) {
+	code, stdout, stderr := runCommand(t, "graph", "./testdata/app")
+	if code != 0 {
+		t.Fatalf("expected exit status 0, got %d: %s", code, stderr)
+	}
+	expected := "store\nmigrator\nserver -> store (Store)\nserver -> migrator (DependsOn)\n"
+	if stdout != expected {
+		t.Fatalf("expected graph %q, got %q", expected, stdout)
+	}
+
+	code, stdout, _ = runCommand(t, "graph", "-format", "dot", "./testdata/app")
+	if code != 0 || !strings.Contains(stdout, `"server" -> "store" [label="Store"];`) || !strings.Contains(stdout, `"server" -> "migrator" [style=dashed];`) {
+		t.Fatalf("expected dot graph, got %q", stdout)
+	}
+}
+
+func TestExplainDescribesComponent(t *testing.T) {
+	code, stdout, stderr := runCommand(t, "explain", "store", "./testdata/app")
+	if code != 0 {
+		t.Fatalf("expected exit status 0, got %d: %s", code, stderr)
+	}
+	for _, line := range []string{
+		"store (*main.Store)",
+		"  priority:   10",
+		"  starts:     1 of 3",
+		"  labels:     tier=storage",
+		"    server (Store)",
+	} {
+		if !strings.Contains(stdout, line+"\n") {
+			t.Errorf("expected line %q, got:\n%s", line, stdout)
+		}
+	}
+
+	code, _, stderr = runCommand(t, "explain", "cache", "./testdata/app")
+	if code != 1 || !strings.Contains(stderr, "component 'cache' not found") {
+		t.Fatalf("expected unknown component error, got %d %q", code, stderr)
+	}
+}
+
+func TestInspectRequiresRun(t *testing.T) {
+	code, _, stderr := runCommand(t, "validate", "./testdata/plain")
+	if code != 1 || !strings.Contains(stderr, "its main must call boot.Run or boot.RunApplication") {
+		t.Fatalf("expected missing report error, got %d %q", code, stderr)
+	}
+}
+
+func TestRunRejectsUnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"deploy"}, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected exit status 2, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "usage: ginject <command>") {
+		t.Fatalf("expected usage, got %q", stderr.String())
+	}
+}
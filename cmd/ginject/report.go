@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/esclipez/ginject/boot"
+)
+
+var errUsage = errors.New("invalid arguments")
+
+// packageArg returns the package argument following the first skip arguments
+func packageArg(args []string, skip int) (string, error) {
+	switch len(args) {
+	case skip:
+		return ".", nil
+	case skip + 1:
+		return args[skip], nil
+	default:
+		return "", errUsage
+	}
+}
+
+// loadReport builds the main package pkg and runs it in inspect mode
+func loadReport(cfg *config, pkg string) (*boot.InspectReport, error) {
+	dir, err := os.MkdirTemp("", "ginject-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout)
+	defer cancel()
+
+	binary := filepath.Join(dir, "app")
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+	build := exec.CommandContext(ctx, "go", "build", "-o", binary, pkg)
+	if output, err := build.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to build %s: %v\n%s", pkg, err, output)
+	}
+
+	reportPath := filepath.Join(dir, "report.json")
+	app := exec.CommandContext(ctx, binary)
+	app.Env = append(os.Environ(), boot.ModeEnv+"="+boot.ModeInspect, boot.InspectOutputEnv+"="+reportPath)
+	output, runErr := app.CombinedOutput()
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%s did not report its wiring within %s", pkg, cfg.timeout)
+		}
+		return nil, fmt.Errorf("%s exited without reporting its wiring (%v); its main must call boot.Run or boot.RunApplication\n%s",
+			pkg, runErr, output)
+	}
+	var report boot.InspectReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to read the wiring report of %s: %w", pkg, err)
+	}
+	return &report, nil
+}
+
+// invalid returns the error of an invalid report
+func invalid(report *boot.InspectReport) error {
+	if report.Valid {
+		return nil
+	}
+	return fmt.Errorf("invalid wiring: %s", report.Error)
+}
+
+// order returns the components in start order, or in registration order when
+// the wiring is invalid
+func order(report *boot.InspectReport) []boot.ComponentReport {
+	if len(report.StartOrder) == 0 {
+		return report.Components
+	}
+	byName := make(map[string]boot.ComponentReport, len(report.Components))
+	for _, component := range report.Components {
+		byName[component.Name] = component
+	}
+	components := make([]boot.ComponentReport, 0, len(report.StartOrder))
+	for _, name := range report.StartOrder {
+		components = append(components, byName[name])
+	}
+	return components
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/esclipez/ginject/boot"
+)
+
+type Store struct{}
+
+func (s *Store) Init(context.Context) error {
+	fmt.Println("store initialized")
+	return nil
+}
+
+type Migrator struct{}
+
+type Server struct {
+	Store *Store `autowire:"store"`
+}
+
+func (s *Server) Start(context.Context) error {
+	fmt.Println("server started")
+	return nil
+}
+
+func main() {
+	boot.Object(&Store{}).Name("store").Priority(10).Label("tier", "storage")
+	boot.Object(&Migrator{}).Name("migrator")
+	boot.Object(&Server{}).Name("server").DependsOn("migrator")
+	boot.RunApplication()
+}
@@ -0,0 +1,12 @@
+package main
+
+import "github.com/esclipez/ginject/boot"
+
+type Server struct {
+	Store *Server `autowire:"store"`
+}
+
+func main() {
+	boot.Object(&Server{}).Name("server")
+	boot.RunApplication()
+}
@@ -73,16 +73,15 @@ type AdvancedService struct {
 
 	StructComponent
 
-	*PointerComponent
+	// Allocated by the container when nil
+	*PointerComponent `autowire:"new"`
 
 	// Won't fail if "NonExistentLogger" doesn't exist
 	SpecificOptional Logger `autowire:"NonExistentLogger,optional"`
 }
 
 func NewAdvancedService() *AdvancedService {
-	return &AdvancedService{
-		PointerComponent: &PointerComponent{},
-	}
+	return &AdvancedService{}
 }
 
 func (a *AdvancedService) Start(_ context.Context) error {
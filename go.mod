@@ -1,3 +1,10 @@
 module github.com/esclipez/ginject
 
 go 1.24.4
+
+require golang.org/x/tools v0.42.0
+
+require (
+	golang.org/x/mod v0.33.0 // indirect
+	golang.org/x/sync v0.19.0 // indirect
+)